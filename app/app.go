@@ -46,11 +46,13 @@ type ShareLedgerApp struct {
 	//accountKey *sdk.KVStoreKey
 
 	//keepers
-	bankKeeper     bank.Keeper
-	posKeeper      pKeeper.Keeper
-	bookingKeeper  booking.Keeper
-	assetKeeper    asset.Keeper
-	exchangeKeeper exchange.Keeper
+	bankKeeper      bank.Keeper
+	supplyKeeper    bank.SupplyKeeper
+	allowanceKeeper bank.AllowanceKeeper
+	posKeeper       pKeeper.Keeper
+	bookingKeeper   booking.Keeper
+	assetKeeper     asset.Keeper
+	exchangeKeeper  exchange.Keeper
 
 	// Manage getting and setting accounts
 	accountMapper auth.AccountMapper
@@ -69,11 +71,11 @@ func NewShareLedgerApp(logger log.Logger, db dbm.DB) *ShareLedgerApp {
 	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
 	posKey := sdk.NewKVStoreKey(constants.STORE_POS)
 	exchangeKey := sdk.NewKVStoreKey(constants.STORE_EXCHANGE)
-	//bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
 
 	// Mount Store
 
-	baseApp.MountStoresIAVL(authKey, assetKey, bookingKey, posKey, exchangeKey)
+	baseApp.MountStoresIAVL(authKey, assetKey, bookingKey, posKey, exchangeKey, bankKey)
 	err := baseApp.LoadLatestVersion(authKey)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -95,11 +97,12 @@ func NewShareLedgerApp(logger log.Logger, db dbm.DB) *ShareLedgerApp {
 		assetKey:   assetKey,
 		bookingKey: bookingKey,
 		posKey:     posKey,
+		bankKey:    bankKey,
 		//accountKey:    accountKey,
 		accountMapper: accountMapper,
 	}
 	app.SetupAsset(assetKey)
-	app.SetupBank(accountMapper)
+	app.SetupBank(bankKey, accountMapper)
 	app.SetupPOS(posKey, accountMapper)
 	app.SetupBooking(bookingKey, assetKey, accountMapper)
 	app.SetupExchange(exchangeKey, accountMapper)
@@ -111,12 +114,12 @@ func NewShareLedgerApp(logger log.Logger, db dbm.DB) *ShareLedgerApp {
 	app.cdc = auth.RegisterCodec(app.cdc)
 
 	// Set Tx Fee Calculation
-	app.SetFeeHandler(fee.NewFeeHandler(accountMapper, exchangeKey))
+	app.SetFeeHandler(fee.NewFeeHandler(accountMapper, exchangeKey, app.supplyKeeper))
 
 	// Register InitChain
 	logger.Info("Register Init Chainer")
 	app.SetInitChainer(app.InitChainer)
-	app.SetEndBlocker(EndBlocker(accountMapper, app.posKeeper))
+	app.SetEndBlocker(EndBlocker(accountMapper, app.posKeeper, app.bookingKeeper, app.assetKeeper, app.supplyKeeper))
 	app.SetBeginBlocker(BeginBlocker)
 
 	return app
@@ -152,7 +155,10 @@ func (app *ShareLedgerApp) InitChainer(ctx sdk.Context, req abci.RequestInitChai
 
 	// load the accounts
 	for _, gacc := range genesisState.Accounts {
-		acc := gacc.ToSHRAccount()
+		acc, err := gacc.ToBaseAccount()
+		if err != nil {
+			panic(err)
+		}
 		app.accountMapper.SetAccount(ctx, acc)
 	}
 
@@ -161,6 +167,19 @@ func (app *ShareLedgerApp) InitChainer(ctx sdk.Context, req abci.RequestInitChai
 	if err != nil {
 		panic(err)
 	}
+	// load the supply authorities
+	if err := bank.InitGenesis(ctx, app.supplyKeeper, genesisState.BankData); err != nil {
+		panic(err)
+	}
+
+	// genesis accounts and stakes make the initial supply
+	app.supplyKeeper.InitSupply(ctx, app.accountMapper, app.posKeeper.GetStakedCoins(ctx))
+
+	// load booking settlement params
+	if err := booking.InitGenesis(ctx, app.bookingKeeper, genesisState.BookingData); err != nil {
+		panic(err)
+	}
+
 	for _, val := range abciVals {
 		constants.LOGGER.Info("Validator Init",
 			"Address", fmt.Sprintf("%X", val.Address),
@@ -188,9 +207,17 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) (res abci.Respons
 }
 
 // application updates every end block
-func EndBlocker(am auth.AccountMapper, keeper pKeeper.Keeper) sdk.EndBlocker {
+func EndBlocker(am auth.AccountMapper, keeper pKeeper.Keeper, bookingKeeper booking.Keeper, assetKeeper asset.Keeper, supplyKeeper bank.SupplyKeeper) sdk.EndBlocker {
 	return func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 
+		// Advance bookings and settle deposits which are due
+		bookingTags := booking.EndBlocker(ctx, bookingKeeper)
+
+		// Drop asset changes older than the history retention window
+		asset.EndBlocker(ctx, assetKeeper)
+
+		checkSupply(ctx, am, keeper, supplyKeeper)
+
 		proposer := ctx.BlockHeader().Proposer
 
 		//	fmt.Printf("Proposer: %v\n", proposer)
@@ -217,6 +244,7 @@ func EndBlocker(am auth.AccountMapper, keeper pKeeper.Keeper) sdk.EndBlocker {
 		// Add these new validators to the addr -> pubkey map.
 		return abci.ResponseEndBlock{
 			ValidatorUpdates: validatorUpdates,
+			Tags:             bookingTags,
 		}
 
 		// Add these new validators to the addr -> pubkey map.
@@ -224,6 +252,29 @@ func EndBlocker(am auth.AccountMapper, keeper pKeeper.Keeper) sdk.EndBlocker {
 	}
 }
 
+// checkSupply - every SUPPLY_INVARIANT_PERIOD blocks, compare the supply with
+// the coins of all accounts and stakes. A mismatch halts the chain if
+// SUPPLY_INVARIANT_HALT is set, otherwise it is logged.
+func checkSupply(ctx sdk.Context, am auth.AccountMapper, keeper pKeeper.Keeper, supplyKeeper bank.SupplyKeeper) {
+	if constants.SUPPLY_INVARIANT_PERIOD <= 0 || ctx.BlockHeight()%constants.SUPPLY_INVARIANT_PERIOD != 0 {
+		return
+	}
+
+	err := bank.SupplyInvariant(ctx, am, supplyKeeper, keeper.GetStakedCoins(ctx))
+	if err == nil {
+		return
+	}
+
+	if constants.SUPPLY_INVARIANT_HALT {
+		panic(err)
+	}
+
+	constants.LOGGER.Error("Supply invariant broken",
+		"Height", ctx.BlockHeight(),
+		"Error", err.Error(),
+	)
+}
+
 func MakeCodec() *wire.Codec {
 	cdc := wire.NewCodec()
 	cdc.RegisterInterface((*types.SHRTx)(nil), nil)
@@ -236,7 +287,9 @@ func MakeCodec() *wire.Codec {
 	cdc.RegisterConcrete(auth.AuthSig{}, "shareledger/AuthSig", nil)
 
 	cdc.RegisterInterface((*auth.BaseAccount)(nil), nil)
-	cdc.RegisterConcrete(auth.SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterConcrete(&auth.SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterConcrete(&auth.ContinuousVestingAccount{}, "shareledger/ContinuousVestingAccount", nil)
+	cdc.RegisterConcrete(&auth.PeriodicVestingAccount{}, "shareledger/PeriodicVestingAccount", nil)
 
 	cdc.RegisterInterface((*types.PubKey)(nil), nil)
 	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
@@ -248,26 +301,32 @@ func MakeCodec() *wire.Codec {
 	return cdc
 }
 
-func (app *ShareLedgerApp) SetupBank(am auth.AccountMapper) {
+func (app *ShareLedgerApp) SetupBank(bankKey *sdk.KVStoreKey, am auth.AccountMapper) {
 	// Bank module
 	// Create a key for accessing the account store.
 	app.cdc = bank.RegisterCodec(app.cdc)
 	app.bankKeeper = bank.NewKeeper(am /*, cdc*/)
+	app.supplyKeeper = bank.NewSupplyKeeper(bankKey)
+	app.allowanceKeeper = bank.NewAllowanceKeeper(bankKey)
 	// Register message routes.
 	// Note the handler gets access to the account store.
 	app.Router().
-		AddRoute("bank", bank.NewHandler(am))
+		AddRoute("bank", bank.NewHandler(am, app.supplyKeeper, app.allowanceKeeper))
+	app.QueryRouter().
+		AddRoute("bank", bank.NewQuerier(app.supplyKeeper, app.allowanceKeeper, app.cdc))
 
 }
 
 func (app *ShareLedgerApp) SetupAsset(assetKey *sdk.KVStoreKey) {
 
-	keeper := asset.NewKeeper(assetKey, app.cdc)
+	app.assetKeeper = asset.NewKeeper(assetKey, app.cdc)
 
 	app.cdc = asset.RegisterCodec(app.cdc)
 
 	app.Router().
-		AddRoute("asset", asset.NewHandler(keeper))
+		AddRoute("asset", asset.NewHandler(app.assetKeeper))
+	app.QueryRouter().
+		AddRoute("asset", asset.NewQuerier(app.assetKeeper, app.cdc))
 
 	// app.MountStoresIAVL(assetKey)
 }
@@ -280,10 +339,13 @@ func (app *ShareLedgerApp) SetupBooking(bookingKey *sdk.KVStoreKey,
 	app.bookingKeeper = booking.NewKeeper(bookingKey,
 		assetKey,
 		am,
+		app.supplyKeeper,
 		app.cdc)
 
 	app.Router().
 		AddRoute("booking", booking.NewHandler(app.bookingKeeper))
+	app.QueryRouter().
+		AddRoute("booking", booking.NewQuerier(app.bookingKeeper, app.cdc))
 
 	// app.MountStoresIAVL(bookingKey)
 
@@ -293,7 +355,7 @@ func (app *ShareLedgerApp) SetupPOS(posKey *sdk.KVStoreKey,
 	am auth.AccountMapper) {
 	app.cdc = pos.RegisterCodec(app.cdc)
 	bankKeeper := bank.NewKeeper(am)
-	app.posKeeper = pKeeper.NewKeeper(posKey, bankKeeper, app.cdc)
+	app.posKeeper = pKeeper.NewKeeper(posKey, bankKeeper, app.supplyKeeper, app.cdc)
 	app.Router().AddRoute("pos", pos.NewHandler(app.posKeeper))
 	app.QueryRouter().
 		AddRoute("pos", pos.NewQuerier(app.posKeeper, app.cdc))
@@ -305,6 +367,6 @@ func (app *ShareLedgerApp) SetupPOS(posKey *sdk.KVStoreKey,
 func (app *ShareLedgerApp) SetupExchange(exchangeKey *sdk.KVStoreKey, am auth.AccountMapper) {
 	app.cdc = exchange.RegisterCodec(app.cdc)
 	bankKeeper := bank.NewKeeper(am)
-	app.exchangeKeeper = exchange.NewKeeper(exchangeKey, bankKeeper)
+	app.exchangeKeeper = exchange.NewKeeper(exchangeKey, bankKeeper, app.supplyKeeper)
 	app.Router().AddRoute("exchangerate", exchange.NewHandler(app.exchangeKeeper))
 }
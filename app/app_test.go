@@ -0,0 +1,87 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/abci/types"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/x/asset"
+	"github.com/sharering/shareledger/x/asset/messages"
+	"github.com/sharering/shareledger/x/auth"
+)
+
+type testKey struct {
+	pub  types.PubKeySecp256k1
+	priv types.PrivKeySecp256k1
+}
+
+func newTestKey() testKey {
+	pub, priv := types.GenerateKeyPair()
+	return testKey{pub, priv}
+}
+
+func (k testKey) address() sdk.Address {
+	return k.pub.Address()
+}
+
+// setupTestApp - app initialised from a generated genesis where every account
+// holds 1000 of the fee denom, in its first block
+func setupTestApp(t *testing.T, accounts ...sdk.Address) *ShareLedgerApp {
+	constants.LOGGER = log.NewNopLogger()
+	app := NewShareLedgerApp(log.NewNopLogger(), dbm.NewMemDB())
+
+	valPub, _ := types.GenerateKeyPair()
+	genesis := GenerateGenesisState(valPub)
+	for _, addr := range accounts {
+		coins := types.NewDefaultCoins()
+		coins = coins.Plus(types.NewCoin(constants.FEE_DENOM, 1000))
+		genesis.Accounts = append(genesis.Accounts, GenesisAccount{Address: addr, Coins: coins})
+	}
+
+	stateBytes, err := app.cdc.MarshalJSON(genesis)
+	require.Nil(t, err)
+
+	app.InitChain(abci.RequestInitChain{AppStateBytes: stateBytes})
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1, Time: 1000000}})
+	return app
+}
+
+func deliver(t *testing.T, app *ShareLedgerApp, key testKey, msg sdk.Msg, nonce int64) abci.ResponseDeliverTx {
+	txBytes, err := app.cdc.MarshalBinary(auth.GetAuthTx(key.pub, key.priv, msg, nonce))
+	require.Nil(t, err)
+	return app.DeliverTx(txBytes)
+}
+
+func TestAssetOwnershipThroughApp(t *testing.T) {
+	owner := newTestKey()
+	app := setupTestApp(t, owner.address())
+
+	res := deliver(t, app, owner, messages.NewMsgCreate(owner.address(), []byte("hash"), "asset-1", true, 10), 1)
+	require.True(t, res.IsOK(), res.Log)
+
+	unauthorized := uint32(sdk.ToABCICode(asset.DefaultCodespace, asset.CodeUnauthorized))
+
+	// Strangers can neither take over, update nor delete the asset
+	stranger := newTestKey()
+	res = deliver(t, app, stranger, messages.NewMsgCreate(owner.address(), []byte("hash"), "asset-2", true, 10), 1)
+	require.Equal(t, unauthorized, res.Code, res.Log)
+
+	stranger = newTestKey()
+	res = deliver(t, app, stranger, messages.NewMsgUpdate(stranger.address(), []byte("hash"), "asset-1", true, 1), 1)
+	require.Equal(t, unauthorized, res.Code, res.Log)
+
+	stranger = newTestKey()
+	res = deliver(t, app, stranger, messages.NewMsgDelete("asset-1"), 1)
+	require.Equal(t, unauthorized, res.Code, res.Log)
+
+	// The asset is still there for its owner
+	res = deliver(t, app, owner, messages.NewMsgUpdate(owner.address(), []byte("new-hash"), "asset-1", true, 20), 2)
+	require.True(t, res.IsOK(), res.Log)
+}
@@ -4,13 +4,17 @@ import (
 	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
 	"github.com/sharering/shareledger/types"
 	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank"
+	"github.com/sharering/shareledger/x/booking"
 	"github.com/sharering/shareledger/x/pos"
 )
 
 // State to Unmarshal
 type GenesisState struct {
-	Accounts  []GenesisAccount `json:"accounts"`
-	StakeData pos.GenesisState `json:"stake"`
+	Accounts    []GenesisAccount     `json:"accounts"`
+	StakeData   pos.GenesisState     `json:"stake"`
+	BookingData booking.GenesisState `json:"booking"`
+	BankData    bank.GenesisState    `json:"bank"`
 }
 
 func (gs *GenesisState) ToJSON() []byte {
@@ -24,10 +28,17 @@ func (gs *GenesisState) ToJSON() []byte {
 	return jsonBytes
 }
 
-// GenesisAccount doesn't need pubkey or sequence
+// GenesisAccount doesn't need pubkey or sequence. Accounts with
+// OriginalVesting vest continuously from StartTime to EndTime, accounts with
+// VestingPeriods vest periodically from StartTime.
 type GenesisAccount struct {
 	Address sdk.Address `json:"address"`
 	Coins   types.Coins `json:"coins"`
+
+	OriginalVesting types.Coins          `json:"original_vesting,omitempty"`
+	VestingPeriods  []auth.VestingPeriod `json:"vesting_periods,omitempty"`
+	StartTime       int64                `json:"start_time,omitempty"`
+	EndTime         int64                `json:"end_time,omitempty"`
 }
 
 func NewGenesisAccount(acc *auth.SHRAccount) GenesisAccount {
@@ -45,8 +56,30 @@ func (ga *GenesisAccount) ToSHRAccount() (acc *auth.SHRAccount) {
 	}
 }
 
+// convert GenesisAccount to a vesting account when it has a vesting schedule
+func (ga *GenesisAccount) ToBaseAccount() (auth.BaseAccount, error) {
+	acc := ga.ToSHRAccount()
+
+	switch {
+	case len(ga.VestingPeriods) > 0:
+		if err := auth.ValidateVestingPeriods(ga.StartTime, ga.VestingPeriods); err != nil {
+			return nil, err
+		}
+		return auth.NewPeriodicVestingAccount(*acc, ga.StartTime, ga.VestingPeriods), nil
+	case len(ga.OriginalVesting) > 0:
+		if err := auth.ValidateVestingSchedule(ga.OriginalVesting, ga.StartTime, ga.EndTime); err != nil {
+			return nil, err
+		}
+		return auth.NewContinuousVestingAccount(*acc, ga.OriginalVesting, ga.StartTime, ga.EndTime), nil
+	default:
+		return acc, nil
+	}
+}
+
 func GenerateGenesisState(pubKey types.PubKeySecp256k1) GenesisState {
 	return GenesisState{
-		StakeData: pos.GenerateGenesis(pubKey),
+		StakeData:   pos.GenerateGenesis(pubKey),
+		BookingData: booking.DefaultGenesisState(),
+		BankData:    bank.DefaultGenesisState(),
 	}
 }
@@ -26,7 +26,9 @@ type TestShareLedgerApp struct {
 	//accountKey *sdk.KVStoreKey
 
 	//keepers
-	bankKeeper bank.Keeper
+	bankKeeper      bank.Keeper
+	supplyKeeper    bank.SupplyKeeper
+	allowanceKeeper bank.AllowanceKeeper
 
 	// Manage getting and setting accounts
 	accountMapper auth.AccountMapper
@@ -40,11 +42,11 @@ func NewTestShareLedgerApp(logger log.Logger, db dbm.DB) *TestShareLedgerApp {
 	baseApp := bapp.NewBaseApp(appName, cdc, logger, db)
 
 	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
-	//bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
 
 	// Mount Store
 
-	baseApp.MountStoresIAVL(authKey)
+	baseApp.MountStoresIAVL(authKey, bankKey)
 	err := baseApp.LoadLatestVersion(authKey)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -73,7 +75,7 @@ func NewTestShareLedgerApp(logger log.Logger, db dbm.DB) *TestShareLedgerApp {
 		AddRoute(constants.MESSAGE_AUTH, auth.NewHandler(accountMapper))
 	app.cdc = auth.RegisterCodec(app.cdc)
 
-	app.SetupBank(accountMapper)
+	app.SetupBank(bankKey, accountMapper)
 
 	// Set Tx Fee Calculation
 	// app.SetFeeHandler(fee.NewFeeHandler(accountMapper, exchangeKey))
@@ -87,15 +89,17 @@ func NewTestShareLedgerApp(logger log.Logger, db dbm.DB) *TestShareLedgerApp {
 	return app
 }
 
-func (app *TestShareLedgerApp) SetupBank(am auth.AccountMapper) {
+func (app *TestShareLedgerApp) SetupBank(bankKey *sdk.KVStoreKey, am auth.AccountMapper) {
 	// Bank module
 	// Create a key for accessing the account store.
 	app.cdc = bank.RegisterCodec(app.cdc)
 	app.bankKeeper = bank.NewKeeper(am /*, cdc*/)
+	app.supplyKeeper = bank.NewSupplyKeeper(bankKey)
+	app.allowanceKeeper = bank.NewAllowanceKeeper(bankKey)
 	// Register message routes.
 	// Note the handler gets access to the account store.
 	app.Router().
-		AddRoute("bank", bank.NewHandler(am))
+		AddRoute("bank", bank.NewHandler(am, app.supplyKeeper, app.allowanceKeeper))
 	app.Router().
 		AddRoute("test", GetHandler())
 
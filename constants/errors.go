@@ -15,11 +15,57 @@ const BOOKING_COMPLETED_ERROR = "The booking %s is already completed."
 const BOOKING_ASSET_NOT_RENTED = "Asset %s is not rented."
 const BOOKING_ASSET_RENTED = "Asset %s is already rented."
 const BOOKING_INSUFFICIENT_BALANCE = "Account %s has insuficient balance."
+const BOOKING_NOT_FOUND = "Booking %s cannot be found."
+const BOOKING_ID_EXISTS = "Booking ID %s already exists."
+const BOOKING_INVALID_WINDOW = "Booking end time %d must be after start time %d."
+const BOOKING_START_IN_PAST = "Booking start time %d is before current block time %d."
+const BOOKING_NOT_STARTED = "The booking %s has not started yet. Start time %d, current block time %d."
+const BOOKING_OVERLAP = "Asset %s is already reserved from %d to %d by booking %s."
+const BOOKING_ASSET_UNAVAILABLE = "Asset %s is not available for booking."
+const BOOKING_CANCELLED_ERROR = "The booking %s is already cancelled."
+const BOOKING_CANCEL_UNAUTHORIZED = "Account %s is neither renter nor owner of booking %s."
+const BOOKING_ALREADY_ENDED = "The booking %s ended at %d. Current block time %d."
+const BOOKING_NOT_COMPLETED = "The booking %s is not completed."
+const BOOKING_CLAIM_UNAUTHORIZED = "Only owner %s of asset %s can claim damages."
+const BOOKING_CLAIM_WINDOW_CLOSED = "Claim window of booking %s closed at %d. Current block time %d."
+const BOOKING_CLAIM_EXISTS = "A damage claim has already been filed for booking %s."
+const BOOKING_INVALID_CLAIM = "Claimed amount %d must be positive and not exceed deposit %d."
+const BOOKING_DISPUTE_OPEN = "Funds of booking %s are frozen by an open dispute."
+const BOOKING_DISPUTE_EXISTS = "A dispute has already been opened for booking %s."
+const BOOKING_DISPUTE_NOT_OPEN = "There is no open dispute for booking %s."
+const BOOKING_DISPUTE_UNAUTHORIZED = "Account %s is neither renter nor owner of booking %s."
+const BOOKING_NOTHING_TO_DISPUTE = "Booking %s has no funds left in escrow."
+const BOOKING_NOT_ARBITER = "Account %s is not an arbiter of asset %s."
+const BOOKING_RENTER_IS_ARBITER = "Account %s arbitrates asset %s and cannot book it."
+const BOOKING_INVALID_RULING = "Renter amount %d must be between 0 and escrowed amount %d."
+const BOOKING_INVALID_TRANSITION = "Booking %s cannot go from %s to %s."
+const BOOKING_NOT_REQUESTED = "The booking %s is not waiting for approval."
+const BOOKING_APPROVE_UNAUTHORIZED = "Only owner %s or an operator of asset %s can approve or reject bookings."
+const BOOKING_REQUEST_EXPIRED = "The booking request %s expired at start time %d. Current block time %d."
+const BOOKING_INVALID_EXTENSION = "Extension must be a positive number of periods. Provided %d."
+const BOOKING_EXTEND_UNAUTHORIZED = "Only renter %s can extend booking %s."
+const BOOKING_NOT_EXTENDABLE = "The booking %s in state %s cannot be extended."
+const BOOKING_INVALID_SHARES = "Commission %s and referral share %s must be positive and add up to at most 1."
+const BOOKING_MISSING_TREASURY = "A treasury address is required to collect commission."
+const BOOKING_INVALID_REFERRER = "Renter cannot refer their own booking."
+const BOOKING_INVALID_SCORE = "Score %d must be between %d and %d."
+const BOOKING_ALREADY_RATED = "You have already rated booking %s."
+const BOOKING_RATE_UNAUTHORIZED = "Account %s is not a party of booking %s."
+const BOOKING_INVALID_DURATION = "Booking of %d units is outside the asset limits. Minimum %d, maximum %d (0 is unlimited)."
+const BOOKING_PRICE_OVERFLOW = "Price of %d units at rate %s exceeds the largest amount."
+const BOOKING_INVALID_PARAMS = "Unmarshal to Query params failed. %s"
+const BOOKING_MARSHAL_ERROR = "Marshal to JSON failed. %s"
 
 // SHRAccount
 const SHRACCOUNT_EXISITNG_ADDRESS = "Address already exists."
 const SHRACCOUNT_INVALID_ADDRESS = "Invalid address."
 
+// Vesting Account
+const VESTING_INVALID_TIME = "Vesting must end after it starts. Start %d, end %d."
+const VESTING_INVALID_AMOUNT = "Invalid vesting amount %s."
+const VESTING_INVALID_PERIODS = "Vesting periods must be non empty with positive lengths."
+const VESTING_INSUFFICIENT_SPENDABLE = "Insufficient spendable coins. Spendable %s, requested %s."
+
 // Proto Error
 const ACCOUNT_INVALID_STRUCT = "accountMapper requires a struct proto BaseAccount, or a pointer to one"
 const ACCOUNT_INVALID_INTERFACE = "accountMapper requries a proto BaseAccount, but %v doesn't implement BaseAccount interface."
@@ -54,8 +100,62 @@ const EXC_INSUFFICIENT_BALANCE = "Account (%s < %s) or Reserve (%s < %s) has ins
 const EXC_ALREADY_EXIST = "Exchange Rate from %s to %s has already existed."
 
 // RESERVE
-const RES_RESERVE_ONLY = "Only priviledged accounts can execute this transaction."
 const RES_OWN_ACCOUNT = "An account can only burn Coins of its own. Account %s != Signer %s."
 
 // BANK
 const BANK_INVALID_BURNT_DENOM = "Only booking denom %s is allowed to be burnt."
+const BANK_INVALID_OUTPUTS = "MsgMultiSend must have between 1 and %d outputs. Provided %d."
+const BANK_NOT_SENDER = "Message must be signed by the sender %s."
+const BANK_INVALID_DENOM = "Denom %s is not supported."
+const BANK_SUPPLY_MISMATCH = "Supply of %s is %s but accounts and stakes hold %s."
+const BANK_INVALID_PARAMS = "Unmarshal to Query params failed. %s"
+const BANK_MARSHAL_ERROR = "Marshal to JSON failed. %s"
+const BANK_DUPLICATE_DENOM = "Denom %s appears more than once."
+
+// ALLOWANCES
+const ALLOWANCE_INVALID_EXPIRATION = "Invalid allowance expiration %d."
+const ALLOWANCE_SELF = "Account %s cannot grant an allowance to itself."
+const ALLOWANCE_EXPIRED_GRANT = "Allowance expiration %d is already past."
+const ALLOWANCE_NOT_FOUND = "Account %s has no allowance from %s."
+const ALLOWANCE_EXPIRED = "Allowance of %s from %s expired at %d."
+const ALLOWANCE_EXCEEDED = "Allowance of %s from %s is %s. Requested %s."
+
+// SUPPLY AUTHORITIES
+const AUTHORITY_NOT_GRANTED = "Account %s is not a %s."
+const AUTHORITY_NOT_FOUND = "Account %s is not a supply authority."
+const AUTHORITY_MINT_CAP_EXCEEDED = "Minter %s may mint at most %s. Requested %s."
+const AUTHORITY_WINDOW_LIMIT_EXCEEDED = "Minter %s may mint at most %s every %d blocks. Requested %s."
+const AUTHORITY_NOT_ADMIN = "Account %s is not an authority admin."
+const AUTHORITY_INVALID_CHANGE = "Invalid authority change %s."
+const AUTHORITY_INVALID_GENESIS = "Invalid supply authorities at genesis. %s"
+const AUTHORITY_PROPOSAL_NOT_FOUND = "Authority proposal %d not found."
+const AUTHORITY_PROPOSAL_CLOSED = "Authority proposal %d was already executed or has expired."
+const AUTHORITY_ALREADY_APPROVED = "Account %s already approved authority proposal %d."
+
+// ASSET
+const ASSET_NOT_OWNER = "Account %s is not the owner of Asset %s."
+const ASSET_RENTED = "Asset %s is currently rented."
+const ASSET_NOT_MANAGER = "Account %s is neither the owner nor an operator of Asset %s."
+const ASSET_OPERATOR_EXISTS = "Account %s already manages Asset %s."
+const ASSET_OPERATOR_NOT_FOUND = "Account %s is not an operator of Asset %s."
+const ASSET_INVALID_SHARES = "Shares must be between 1 and %d. Provided %d."
+const ASSET_INSUFFICIENT_SHARES = "Account %s holds %d shares of Asset %s. Required %d."
+const ASSET_EXISTS = "Asset %s already exists."
+const ASSET_INVALID_BATCH_SIZE = "Batch must contain between 1 and %d assets. Provided %d."
+const ASSET_DUPLICATE_IN_BATCH = "Asset %s appears more than once in the batch."
+const ASSET_BATCH_FAILED = "Batch refused, no asset was changed. Failed items: %s"
+const ASSET_MISSING_SIGNER = "Asset transaction requires a signer."
+const ASSET_INVALID_DEPOSIT = "Deposit must not be negative. Provided deposit %d."
+const ASSET_INVALID_LATE_FEE = "Late fee must not be negative. Provided late fee %d."
+const ASSET_INVALID_EARLY_RETURN = "Early return refund must be between 0 and 100 percent. Provided %d."
+const ASSET_INVALID_REFUND_POLICY = "Invalid refund policy %s with percent %d."
+const ASSET_INVALID_UUID = "UUID must be 1 to %d letters, digits, '-' or '_'. Provided %q."
+const ASSET_INVALID_CATEGORY = "Category must be at most %d lowercase letters, digits or '-'. Provided %s."
+const ASSET_INVALID_GEOHASH = "Geohash must be at most %d base32 characters. Provided %s."
+const ASSET_FIELD_TOO_LONG = "Asset %s must be at most %d bytes. Provided %d bytes."
+const ASSET_MISSING_CONTENT_HASH = "Asset metadata with a URI requires a content hash."
+const ASSET_INVALID_PRICING = "Invalid pricing model. Denom must be allowed, unit positive, rate between 0 and the largest amount, max units at least min units and tiers increasing with discounts below 1."
+const ASSET_INVALID_PARAMS = "Unmarshal to Query params failed. %s"
+const ASSET_MARSHAL_ERROR = "Marshal to JSON failed. %s"
+const ASSET_INVALID_ARBITER = "Account %s cannot arbitrate Asset %s as its owner, operator or renter."
+const ASSET_ARBITER_LOCKED = "Arbiter of Asset %s cannot change while %d disputes are open."
@@ -16,6 +16,29 @@ var LEVELS = map[string]FeeLevel{
 	"MsgDelete":   LOW,
 	"MsgBook":     HIGH,
 	"MsgComplete": MED,
+
+	"MsgTransferAsset": MED,
+	"MsgCancelBooking": MED,
+	"MsgClaimDamage":   MED,
+
+	"MsgOpenDispute":    MED,
+	"MsgResolveDispute": LOW,
+
+	"MsgApproveBooking": LOW,
+	"MsgRejectBooking":  LOW,
+	"MsgExtendBooking":  MED,
+	"MsgRate":           LOW,
+
+	"MsgGrantOperator":  LOW,
+	"MsgRevokeOperator": LOW,
+	"MsgTransferShares": MED,
+
+	"MsgProposeAuthorityChange": LOW,
+	"MsgApproveAuthorityChange": LOW,
+
+	"MsgGrantAllowance":  LOW,
+	"MsgRevokeAllowance": LOW,
+	"MsgSendFrom":        LOW,
 }
 
 var FEE_LEVELS = map[FeeLevel]int{
@@ -5,7 +5,8 @@ import "time"
 // APP ACCOUNT
 const DEFAULT_DENOM = "SHR"
 const DEFAULT_AMOUNT = 0
-const PREFIX_ADDRESS = "account:" // address to string to store in Auth Module
+const PREFIX_ADDRESS = "account:"               // address to string to store in Auth Module
+const PREFIX_BOOKING_ESCROW = "booking/escrow:" // seed of escrow account addresses of bookings
 
 // STORE
 const STORE_BANK = "bank"
@@ -36,8 +37,46 @@ var UNBONDING_TIME time.Duration = 60 * 60 * 24 * 3 * time.Second //3 weeks -> a
 //POS Constant
 var MIN_MASTER_NODE_TOKEN int64 = 2000000
 
-// EXCHANGE
-var RESERVE_ACCOUNTS = []string{
-	"405C725BC461DCA455B8AA84769E8ACE6B3763F4",
-	"B87D5A84F7DCE488BA2FCBDD2057023561BC05A4",
-}
+// BANK
+var BANK_MAX_OUTPUTS = 100              // recipients allowed in one MsgMultiSend
+var SUPPLY_INVARIANT_PERIOD int64 = 100 // blocks between supply invariant checks, 0 disables the check
+var SUPPLY_INVARIANT_HALT = false       // halt the chain on a supply mismatch instead of logging it
+
+// SUPPLY AUTHORITIES
+var AUTHORITY_MINT_WINDOW int64 = 17280   // blocks of a minter window limit, about a day
+var AUTHORITY_PROPOSAL_TTL int64 = 120960 // blocks an authority change proposal stays open, about a week
+
+// ASSET HISTORY
+var ASSET_HISTORY_RETENTION int64 = 0 // blocks an asset change stays queryable, 0 keeps every change
+var ASSET_HISTORY_PRUNE_LIMIT = 100   // asset changes pruned per block
+
+// ASSET BATCH
+var ASSET_MAX_BATCH_SIZE = 100 // creates and updates allowed in one MsgBatchAssets
+
+// ASSET SHARES
+var ASSET_TOTAL_SHARES int64 = 10000 // shares of every asset, one share is 0.01%
+
+// ASSET METADATA
+var ASSET_MAX_UUID_LENGTH = 64         // letters, digits, '-' and '_'
+var ASSET_MAX_CATEGORY_LENGTH = 32     // lowercase letters, digits and '-'
+var ASSET_MAX_GEOHASH_LENGTH = 12      // base32 characters, 12 is a cell of a few centimetres
+var ASSET_MAX_TITLE_LENGTH = 128       // bytes
+var ASSET_MAX_URI_LENGTH = 256         // bytes
+var ASSET_MAX_CONTENT_HASH_LENGTH = 64 // bytes
+
+// BOOKING
+var BOOKING_TIME_UNIT int64 = 60 * 60                // seconds per billable unit. Asset fee is charged per started unit
+var BOOKING_CLAIM_WINDOW int64 = 60 * 60 * 24 * 3    // seconds after completion for owner to claim damages
+var BOOKING_DISPUTE_WINDOW int64 = 60 * 60 * 24 * 3  // seconds after a damage claim before it pays out
+var BOOKING_DISPUTE_TIMEOUT int64 = 60 * 60 * 24 * 7 // seconds for an arbiter to rule before the default outcome applies
+var BOOKING_GRACE_PERIOD int64 = 60 * 60             // seconds after end time before a booking is overdue
+var BOOKING_MAX_LATE_PERIODS int64 = 24 * 3          // late fees charged before an overdue booking expires anyway
+var BOOKING_QUEUE_LIMIT = 100                        // entries of each booking queue processed per block
+
+// RATING
+const RATING_MIN_SCORE int64 = 1
+const RATING_MAX_SCORE int64 = 5
+
+// QUERY
+var QUERY_DEFAULT_LIMIT = 30 // results per page when a query sets no limit
+var QUERY_MAX_LIMIT = 100    // upper bound of results per page
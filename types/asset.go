@@ -1,28 +1,44 @@
 package types
 
 import (
+	"bytes"
 	// "encoding/hex"
-    "encoding/json"
+	"encoding/json"
 	"fmt"
+
 	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
 	// "strconv"
+
+	"github.com/sharering/shareledger/constants"
 )
 
 // Asset asset infomation
 type Asset struct {
-	UUID    string      `json:"uuid"`
-	Hash    []byte      `json:"hash"`
-	Creator sdk.Address `json:"creator"`
-	Status  bool        `json:"status"`
-	Fee     int64       `json:"fee"`
+	UUID             string        `json:"uuid"`
+	Hash             []byte        `json:"hash"`
+	Creator          sdk.Address   `json:"creator"`
+	Status           bool          `json:"status"`
+	Fee              int64         `json:"fee"`
+	Deposit          int64         `json:"deposit"` // refundable security deposit locked with each booking
+	Refund           RefundPolicy  `json:"refund_policy"`
+	Arbiter          sdk.Address   `json:"arbiter,omitempty"`   // resolves disputes of this asset besides global arbiters
+	ApprovalRequired bool          `json:"approval_required"`   // bookings wait for owner approval instead of instant booking
+	LateFee          int64         `json:"late_fee"`            // charged per time unit overdue. Zero expires overdue bookings instead
+	EarlyReturn      int64         `json:"early_return"`        // percent of unused whole time units refunded on early return
+	Metadata         AssetMetadata `json:"metadata"`            // category, location and off chain description
+	Pricing          *PricingModel `json:"pricing,omitempty"`   // replaces Fee per BOOKING_TIME_UNIT in BOOKING_DENOM when set
+	Operators        []sdk.Address `json:"operators,omitempty"` // manage pricing, metadata and bookings on behalf of Creator
+	Shares           []Share       `json:"shares,omitempty"`    // cap table of co-owned assets, see GetCapTable
+	Calendar         []Reservation `json:"calendar,omitempty"`  // outstanding reservations, sorted by StartTime
+	OpenDisputes     int64         `json:"open_disputes"`       // bookings with an open dispute, Arbiter cannot change meanwhile
 }
 
 func (a Asset) String() string {
-    b, err := json.Marshal(a)
-    if err != nil {
-        panic(err)
-    }
-    return fmt.Sprintf("%s", b)
+	b, err := json.Marshal(a)
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf("%s", b)
 }
 
 //--------------------------------------------------------
@@ -38,3 +54,185 @@ func NewAsset(uuid string, creator sdk.Address, hash []byte, status bool, fee in
 		Fee:     fee,
 	}
 }
+
+//--------------------------------------------------------
+// Operators
+
+// IsOperator - whether addr was granted operator rights by the owner
+func (a Asset) IsOperator(addr sdk.Address) bool {
+	for _, o := range a.Operators {
+		if bytes.Equal(o, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanManage - whether addr is the owner or an operator of the asset
+func (a Asset) CanManage(addr sdk.Address) bool {
+	return bytes.Equal(a.Creator, addr) || a.IsOperator(addr)
+}
+
+// RemoveOperator - revoke operator rights of addr
+func (a *Asset) RemoveOperator(addr sdk.Address) bool {
+	for i, o := range a.Operators {
+		if bytes.Equal(o, addr) {
+			a.Operators = append(a.Operators[:i], a.Operators[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+//--------------------------------------------------------
+// Metadata
+
+// AssetMetadata - structured description of an asset. Details live off chain
+// at URI and are pinned by ContentHash.
+type AssetMetadata struct {
+	Category    string `json:"category"`
+	Geohash     string `json:"geohash"` // location of the asset, coarser geohashes cover larger areas
+	Title       string `json:"title"`
+	URI         string `json:"uri"`
+	ContentHash []byte `json:"content_hash"`
+}
+
+func NewAssetMetadata(category string, geohash string, title string, uri string, contentHash []byte) AssetMetadata {
+	return AssetMetadata{
+		Category:    category,
+		Geohash:     geohash,
+		Title:       title,
+		URI:         uri,
+		ContentHash: contentHash,
+	}
+}
+
+//--------------------------------------------------------
+// Calendar
+
+// Reservation - a time window [StartTime, EndTime) of an asset held by a booking
+type Reservation struct {
+	BookingID string      `json:"bookingId"`
+	Renter    sdk.Address `json:"renter,omitempty"` // unset on reservations made before it was recorded
+	StartTime int64       `json:"start_time"`       // unix time
+	EndTime   int64       `json:"end_time"`         // unix time
+}
+
+func NewReservation(bookingID string, renter sdk.Address, start int64, end int64) Reservation {
+	return Reservation{
+		BookingID: bookingID,
+		Renter:    renter,
+		StartTime: start,
+		EndTime:   end,
+	}
+}
+
+// Overlaps - whether this reservation intersects the window [start, end)
+func (r Reservation) Overlaps(start int64, end int64) bool {
+	return r.StartTime < end && start < r.EndTime
+}
+
+// FindConflict - return the first reservation intersecting [start, end)
+func (a Asset) FindConflict(start int64, end int64) (Reservation, bool) {
+	for _, r := range a.Calendar {
+		if r.Overlaps(start, end) {
+			return r, true
+		}
+	}
+	return Reservation{}, false
+}
+
+// Reserve - add a reservation to the calendar keeping it sorted by StartTime.
+// Callers must check FindConflict beforehand.
+func (a *Asset) Reserve(r Reservation) {
+	i := 0
+	for i < len(a.Calendar) && a.Calendar[i].StartTime <= r.StartTime {
+		i++
+	}
+	a.Calendar = append(a.Calendar, Reservation{})
+	copy(a.Calendar[i+1:], a.Calendar[i:])
+	a.Calendar[i] = r
+}
+
+// IsRenter - whether addr holds a reservation of the asset
+func (a Asset) IsRenter(addr sdk.Address) bool {
+	for _, r := range a.Calendar {
+		if len(r.Renter) > 0 && bytes.Equal(r.Renter, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Release - remove the reservation held by bookingID
+func (a *Asset) Release(bookingID string) bool {
+	for i, r := range a.Calendar {
+		if r.BookingID == bookingID {
+			a.Calendar = append(a.Calendar[:i], a.Calendar[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+//--------------------------------------------------------
+// Refund policy
+
+const (
+	REFUND_FULL    = "full"    // full refund before start, nothing after start
+	REFUND_PARTIAL = "partial" // full refund before start, Percent of the price after start
+	REFUND_NONE    = "none"    // no refund at all
+)
+
+// RefundPolicy - how much of the price is returned to a renter cancelling a booking.
+// Empty Kind is treated as REFUND_FULL.
+type RefundPolicy struct {
+	Kind    string `json:"kind"`
+	Percent int64  `json:"percent"` // only used with REFUND_PARTIAL
+}
+
+func NewRefundPolicy(kind string, percent int64) RefundPolicy {
+	return RefundPolicy{
+		Kind:    kind,
+		Percent: percent,
+	}
+}
+
+// IsValid - whether the policy is well formed
+func (p RefundPolicy) IsValid() bool {
+	switch p.Kind {
+	case "", REFUND_FULL, REFUND_NONE:
+		return p.Percent == 0
+	case REFUND_PARTIAL:
+		return p.Percent >= 0 && p.Percent <= 100
+	default:
+		return false
+	}
+}
+
+// GetPricing - pricing model of the asset. Assets without one are charged
+// Fee per started BOOKING_TIME_UNIT in BOOKING_DENOM.
+func (a Asset) GetPricing() PricingModel {
+	if a.Pricing != nil {
+		return *a.Pricing
+	}
+	return NewPricingModel(constants.BOOKING_DENOM, constants.BOOKING_TIME_UNIT, NewDec(a.Fee), 0, 0, nil)
+}
+
+// GetRefund - amount of price refunded when cancelling at time now a booking starting at start
+func (p RefundPolicy) GetRefund(price int64, now int64, start int64) int64 {
+	switch p.Kind {
+	case REFUND_NONE:
+		return 0
+	case REFUND_PARTIAL:
+		if now < start {
+			return price
+		}
+		return price * p.Percent / 100
+	default:
+		if now < start {
+			return price
+		}
+		return 0
+	}
+}
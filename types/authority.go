@@ -0,0 +1,159 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+// roles of a supply authority
+const (
+	AUTHORITY_MINTER  = "minter"  // may load coins with MsgLoad
+	AUTHORITY_BURNER  = "burner"  // may burn its own coins with MsgBurn
+	AUTHORITY_RESERVE = "reserve" // may act as the counterparty of exchanges
+	AUTHORITY_ARBITER = "arbiter" // may resolve disputes of any asset
+)
+
+// Authority - account allowed to change the supply, hold the exchange reserve
+// or arbitrate disputes. Mints of a minter are limited per denom by MintCap
+// over its lifetime and by WindowLimit over each window of
+// AUTHORITY_MINT_WINDOW blocks. Denoms without a cap or limit are not
+// restricted.
+type Authority struct {
+	Address     sdk.Address `json:"address"`
+	Roles       []string    `json:"roles"`
+	MintCap     Coins       `json:"mint_cap,omitempty"`
+	WindowLimit Coins       `json:"window_limit,omitempty"`
+
+	Minted       Coins `json:"minted,omitempty"`        // minted over the lifetime of the authority
+	WindowStart  int64 `json:"window_start"`            // first height of the current mint window
+	WindowMinted Coins `json:"window_minted,omitempty"` // minted during the current window
+}
+
+func NewAuthority(address sdk.Address, roles []string, mintCap Coins, windowLimit Coins) Authority {
+	return Authority{
+		Address:     address,
+		Roles:       roles,
+		MintCap:     mintCap,
+		WindowLimit: windowLimit,
+	}
+}
+
+// HasRole - whether the authority was granted role
+func (a Authority) HasRole(role string) bool {
+	for _, r := range a.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid - address set, at least one known role, caps and limits positive
+// in allowed denoms
+func (a Authority) IsValid() bool {
+	if len(a.Address) == 0 || len(a.Roles) == 0 {
+		return false
+	}
+
+	for _, r := range a.Roles {
+		switch r {
+		case AUTHORITY_MINTER, AUTHORITY_BURNER, AUTHORITY_RESERVE, AUTHORITY_ARBITER:
+		default:
+			return false
+		}
+	}
+
+	for _, c := range append(append(Coins{}, a.MintCap...), a.WindowLimit...) {
+		if !IsValidDenom(c.Denom) || !c.IsPositive() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RecordMint - count amt against the cap and the window limit of the minter at
+// height. A new window starts once window blocks have passed since the
+// current one started. Returns an error, leaving a unchanged, if amt exceeds
+// either of them.
+func (a *Authority) RecordMint(amt Coin, height int64, window int64) error {
+	windowStart, windowMinted := a.WindowStart, a.WindowMinted
+	if height >= windowStart+window {
+		windowStart, windowMinted = height, nil
+	}
+
+	minted := a.Minted.AmountOf(amt.Denom).Add(amt.Amount)
+	if mintCap, found := a.MintCap.Find(amt.Denom); found && minted.GT(mintCap.Amount) {
+		return fmt.Errorf(constants.AUTHORITY_MINT_CAP_EXCEEDED,
+			a.Address,
+			mintCap,
+			amt)
+	}
+
+	inWindow := windowMinted.AmountOf(amt.Denom).Add(amt.Amount)
+	if limit, found := a.WindowLimit.Find(amt.Denom); found && inWindow.GT(limit.Amount) {
+		return fmt.Errorf(constants.AUTHORITY_WINDOW_LIMIT_EXCEEDED,
+			a.Address,
+			limit,
+			window,
+			amt)
+	}
+
+	a.Minted = a.Minted.set(NewCoinFromDec(amt.Denom, minted))
+	a.WindowStart = windowStart
+	a.WindowMinted = windowMinted.set(NewCoinFromDec(amt.Denom, inWindow))
+
+	return nil
+}
+
+// AuthorityChange - change to the supply authorities or to their admins,
+// applied once approved by AdminThreshold admins
+type AuthorityChange struct {
+	Action    string        `json:"action"`
+	Authority Authority     `json:"authority"`           // granted by AUTHORITY_SET, only the address is used by AUTHORITY_REMOVE
+	Admins    []sdk.Address `json:"admins,omitempty"`    // replace the admins with AUTHORITY_SET_ADMINS
+	Threshold int           `json:"threshold,omitempty"` // approvals required once the admins are replaced
+}
+
+// actions of an AuthorityChange
+const (
+	AUTHORITY_SET        = "set_authority"
+	AUTHORITY_REMOVE     = "remove_authority"
+	AUTHORITY_SET_ADMINS = "set_admins"
+)
+
+// IsValid - the fields used by the action are valid
+func (c AuthorityChange) IsValid() bool {
+	switch c.Action {
+	case AUTHORITY_SET:
+		return c.Authority.IsValid()
+	case AUTHORITY_REMOVE:
+		return len(c.Authority.Address) > 0
+	case AUTHORITY_SET_ADMINS:
+		return IsValidAdminSet(c.Admins, c.Threshold)
+	}
+	return false
+}
+
+// IsValidAdminSet - at least one admin, no duplicate, and a threshold between
+// 1 and the number of admins
+func IsValidAdminSet(admins []sdk.Address, threshold int) bool {
+	if threshold < 1 || threshold > len(admins) {
+		return false
+	}
+	for i, a := range admins {
+		if len(a) == 0 {
+			return false
+		}
+		for _, b := range admins[i+1:] {
+			if bytes.Equal(a, b) {
+				return false
+			}
+		}
+	}
+	return true
+}
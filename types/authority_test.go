@@ -0,0 +1,69 @@
+package types
+
+import (
+	"testing"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+)
+
+func TestRecordMint(t *testing.T) {
+	minter := NewAuthority(
+		sdk.Address([]byte("minter")),
+		[]string{AUTHORITY_MINTER},
+		Coins{NewCoin("SHRP", 100)},
+		Coins{NewCoin("SHRP", 40)},
+	)
+
+	table := []struct {
+		amount   Coin
+		height   int64
+		expected bool
+	}{
+		{NewCoin("SHRP", 30), 1, true},
+		{NewCoin("SHRP", 20), 5, false}, // exceeds the window limit
+		{NewCoin("SHRP", 10), 5, true},
+		{NewCoin("SHR", 1000), 5, true},  // denom without cap nor limit
+		{NewCoin("SHRP", 40), 11, true},  // new window
+		{NewCoin("SHRP", 30), 21, false}, // exceeds the cap
+		{NewCoin("SHRP", 20), 21, true},
+	}
+
+	for _, tc := range table {
+		err := minter.RecordMint(tc.amount, tc.height, 10)
+		if (err == nil) != tc.expected {
+			t.Errorf("RecordMint %s at %d should succeed %t but returned %v.", tc.amount, tc.height, tc.expected, err)
+		}
+	}
+
+	if !minter.Minted.AmountOf("SHRP").Equal(NewDec(100)) {
+		t.Errorf("Minted should be 100SHRP but is %s.", minter.Minted)
+	}
+
+	if minter.WindowStart != 21 || !minter.WindowMinted.AmountOf("SHRP").Equal(NewDec(20)) {
+		t.Errorf("Window should start at 21 with 20SHRP but starts at %d with %s.", minter.WindowStart, minter.WindowMinted)
+	}
+}
+
+func TestValidAdminSet(t *testing.T) {
+	a := sdk.Address([]byte("a"))
+	b := sdk.Address([]byte("b"))
+
+	table := []struct {
+		admins    []sdk.Address
+		threshold int
+		expected  bool
+	}{
+		{[]sdk.Address{a, b}, 2, true},
+		{[]sdk.Address{a, b}, 3, false},
+		{[]sdk.Address{a, b}, 0, false},
+		{[]sdk.Address{a, a}, 1, false},
+		{[]sdk.Address{}, 0, false},
+	}
+
+	for _, tc := range table {
+		ret := IsValidAdminSet(tc.admins, tc.threshold)
+		if ret != tc.expected {
+			t.Errorf("%v with threshold %d should be %t but %t returned.", tc.admins, tc.threshold, tc.expected, ret)
+		}
+	}
+}
@@ -1,33 +1,257 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
+
 	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
-	"encoding/json"
+
+	"github.com/sharering/shareledger/constants"
 )
 
 // Simple Booking struct
 type Booking struct {
-	BookingID   string      `json:"bookingId"`
-	Renter      sdk.Address `json:"renter"`
-	UUID        string      `json:"uuid"`
-	Duration    int64       `json:"duration"`
-	IsCompleted bool        `json:"is_completed"`
+	BookingID   string        `json:"bookingId"`
+	Renter      sdk.Address   `json:"renter"`
+	UUID        string        `json:"uuid"`
+	StartTime   int64         `json:"start_time"`      // unix time
+	EndTime     int64         `json:"end_time"`        // unix time
+	Price       int64         `json:"price"`           // amount of Denom paid by renter
+	Deposit     int64         `json:"deposit"`         // amount of Denom locked as security deposit
+	Denom       string        `json:"denom"`           // denom of the asset pricing when booked
+	Terms       *BookingTerms `json:"terms,omitempty"` // asset terms agreed when booked
+	State       string        `json:"state"`
+	CompletedAt int64         `json:"completed_at"` // unix time
+	LatePeriods int64         `json:"late_periods"` // time units charged a late fee
+	LateFees    int64         `json:"late_fees"`    // total late fees paid to owner
+
+	Claim          *DamageClaim `json:"claim,omitempty"`
+	DepositSettled bool         `json:"deposit_settled"`
+	Dispute        *Dispute     `json:"dispute,omitempty"`
+
+	Referrer sdk.Address `json:"referrer,omitempty"` // earns the referral share of the revenue
+	Payouts  []Payout    `json:"payouts,omitempty"`  // revenue paid out at settlement
+
+	RenterRating *Rating `json:"renter_rating,omitempty"` // given by renter to owner and asset
+	OwnerRating  *Rating `json:"owner_rating,omitempty"`  // given by owner to renter
+}
+
+const (
+	BOOKING_REQUESTED = "requested" // waiting for owner approval
+	BOOKING_CONFIRMED = "confirmed" // approved, window not started yet
+	BOOKING_ACTIVE    = "active"    // window started, asset with renter
+	BOOKING_COMPLETED = "completed"
+	BOOKING_CANCELLED = "cancelled"
+	BOOKING_DISPUTED  = "disputed" // escrow frozen until the dispute is resolved
+	BOOKING_EXPIRED   = "expired"  // request not approved in time, or overdue and not returned
+)
+
+// allowed transitions from each booking state
+var bookingTransitions = map[string][]string{
+	BOOKING_REQUESTED: {BOOKING_CONFIRMED, BOOKING_CANCELLED, BOOKING_EXPIRED},
+	BOOKING_CONFIRMED: {BOOKING_ACTIVE, BOOKING_CANCELLED, BOOKING_DISPUTED},
+	BOOKING_ACTIVE:    {BOOKING_COMPLETED, BOOKING_CANCELLED, BOOKING_DISPUTED, BOOKING_EXPIRED},
+	BOOKING_COMPLETED: {BOOKING_DISPUTED},
+	BOOKING_EXPIRED:   {BOOKING_DISPUTED},
+	BOOKING_DISPUTED:  {BOOKING_COMPLETED},
+}
+
+// UnmarshalJSON - bookings stored before lifecycle states only recorded
+// is_completed. They are read as completed or active.
+func (b *Booking) UnmarshalJSON(bz []byte) error {
+	// booking has the fields of Booking without this method
+	type booking Booking
+	stored := struct {
+		*booking
+		IsCompleted bool `json:"is_completed"`
+	}{booking: (*booking)(b)}
+
+	if err := json.Unmarshal(bz, &stored); err != nil {
+		return err
+	}
+
+	if len(b.State) == 0 {
+		b.State = BOOKING_ACTIVE
+		if stored.IsCompleted {
+			b.State = BOOKING_COMPLETED
+		}
+	}
+	return nil
+}
+
+// CanTransition - whether a booking in state from can move to state to
+func CanTransition(from string, to string) bool {
+	for _, s := range bookingTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition - move booking to state to, returning an error for a transition not allowed
+func (b *Booking) Transition(to string) error {
+	if !CanTransition(b.State, to) {
+		return fmt.Errorf(constants.BOOKING_INVALID_TRANSITION, b.BookingID, b.State, to)
+	}
+	b.State = to
+	return nil
+}
+
+// HasOpenDispute - whether funds of this booking are frozen by a dispute
+func (b Booking) HasOpenDispute() bool {
+	return b.State == BOOKING_DISPUTED
+}
+
+// IsOpen - whether the price of this booking is still held in escrow
+func (b Booking) IsOpen() bool {
+	state := b.State
+	if state == BOOKING_DISPUTED {
+		state = b.Dispute.PreviousState
+	}
+
+	switch state {
+	case BOOKING_REQUESTED, BOOKING_CONFIRMED, BOOKING_ACTIVE:
+		return true
+	}
+	return false
+}
+
+// GetEscrowed - amount of the booking still held in escrow
+func (b Booking) GetEscrowed() int64 {
+	var escrowed int64
+	if b.IsOpen() {
+		escrowed += b.Price
+	}
+	if !b.DepositSettled {
+		escrowed += b.Deposit
+	}
+	return escrowed
 }
 
-func NewBooking(_bid string, _acc sdk.Address, _uuid string, _dur int64, _isCompleted bool) Booking {
+// GetDenom - denom of the booking payments. Bookings made before assets had a
+// pricing model are paid in BOOKING_DENOM.
+func (b Booking) GetDenom() string {
+	if len(b.Denom) == 0 {
+		return constants.BOOKING_DENOM
+	}
+	return b.Denom
+}
+
+// BookingTerms - terms of the asset in force when a booking was made. Later
+// changes of the asset do not apply to existing bookings.
+type BookingTerms struct {
+	Refund      RefundPolicy `json:"refund_policy"`
+	Pricing     PricingModel `json:"pricing"`      // prices the booking and its extensions
+	EarlyReturn int64        `json:"early_return"` // percent of unused units refunded on early completion
+}
+
+func NewBookingTerms(asset Asset) BookingTerms {
+	return BookingTerms{
+		Refund:      asset.Refund,
+		Pricing:     asset.GetPricing(),
+		EarlyReturn: asset.EarlyReturn,
+	}
+}
+
+// GetEarlyReturnRefund - refund for returning at now a booking ending at end.
+// Only whole unused pricing units are refunded, at EarlyReturn percent of the rate.
+func (t BookingTerms) GetEarlyReturnRefund(now int64, end int64) int64 {
+	if now >= end {
+		return 0
+	}
+	unused := (end - now) / t.Pricing.Unit
+	return t.Pricing.Rate.Mul(NewDec(unused)).Mul(NewDecWithPrec(t.EarlyReturn, 2)).TruncateInt64()
+}
+
+// GetTerms - terms agreed when booking. Bookings made before terms were
+// recorded follow the current terms of asset.
+func (b Booking) GetTerms(asset Asset) BookingTerms {
+	if b.Terms == nil {
+		return NewBookingTerms(asset)
+	}
+	return *b.Terms
+}
+
+// DamageClaim - claim filed by the owner against the deposit of a completed booking
+type DamageClaim struct {
+	Amount   int64    `json:"amount"`
+	Evidence [][]byte `json:"evidence"` // hashes of off-chain evidence
+	FiledAt  int64    `json:"filed_at"` // unix time
+}
+
+func NewDamageClaim(amount int64, evidence [][]byte, filedAt int64) DamageClaim {
+	return DamageClaim{
+		Amount:   amount,
+		Evidence: evidence,
+		FiledAt:  filedAt,
+	}
+}
+
+func NewBooking(_bid string, _acc sdk.Address, _uuid string, _start int64, _end int64, _price int64, _state string) Booking {
 	return Booking{
 		BookingID: _bid,
 		Renter:    _acc,
 		UUID:      _uuid,
-		Duration:  _dur,
-		IsCompleted: _isCompleted,
+		StartTime: _start,
+		EndTime:   _end,
+		Price:     _price,
+		State:     _state,
 	}
 }
 
+// Duration - length of the booking window in seconds
+func (b Booking) Duration() int64 {
+	return b.EndTime - b.StartTime
+}
+
 func (b Booking) String() string {
-	//return fmt.Sprintf("{BookingID: %s, Renter: %s, UUID: %x, Duration: %d, IsCompleted: %t}",
-	//	b.BookingID, b.Renter, b.UUID, b.Duration, b.IsCompleted)
+	//return fmt.Sprintf("{BookingID: %s, Renter: %s, UUID: %x, Duration: %d, State: %s}",
+	//	b.BookingID, b.Renter, b.UUID, b.Duration, b.State)
 	bookingBytes, _ := json.Marshal(b)
 	return fmt.Sprintf("%s", bookingBytes)
 }
+
+// Dispute - disagreement between renter and owner resolved by an arbiter
+type Dispute struct {
+	OpenedBy      sdk.Address `json:"opened_by"`
+	Reason        string      `json:"reason"`
+	OpenedAt      int64       `json:"opened_at"`         // unix time
+	PreviousState string      `json:"previous_state"`    // state of the booking when the dispute was opened
+	Arbiter       sdk.Address `json:"arbiter,omitempty"` // arbiter of the asset when the dispute was opened
+	Resolved      bool        `json:"resolved"`
+	ResolvedBy    sdk.Address `json:"resolved_by,omitempty"` // empty when the default outcome applied
+	RenterAmount  int64       `json:"renter_amount"`         // escrowed amount awarded to renter, the rest goes to owner
+}
+
+func NewDispute(openedBy sdk.Address, reason string, openedAt int64, previousState string, arbiter sdk.Address) Dispute {
+	return Dispute{
+		OpenedBy:      openedBy,
+		Reason:        reason,
+		OpenedAt:      openedAt,
+		PreviousState: previousState,
+		Arbiter:       arbiter,
+	}
+}
+
+const (
+	PAYOUT_OWNER       = "owner"       // managing owner, also receives rounding dust of the shareholders
+	PAYOUT_SHAREHOLDER = "shareholder" // co-owner of the asset besides the managing owner
+	PAYOUT_TREASURY    = "treasury"
+	PAYOUT_REFERRER    = "referrer"
+)
+
+// Payout - share of booking revenue paid to one recipient
+type Payout struct {
+	Role      string      `json:"role"`
+	Recipient sdk.Address `json:"recipient"`
+	Amount    Dec         `json:"amount"`
+}
+
+func NewPayout(role string, recipient sdk.Address, amount Dec) Payout {
+	return Payout{
+		Role:      role,
+		Recipient: recipient,
+		Amount:    amount,
+	}
+}
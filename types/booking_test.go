@@ -0,0 +1,53 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingTransitions(t *testing.T) {
+	booking := NewBooking("id", nil, "asset", 0, 10, 5, BOOKING_REQUESTED)
+
+	require.NotNil(t, booking.Transition(BOOKING_ACTIVE))
+	require.Equal(t, BOOKING_REQUESTED, booking.State)
+
+	require.Nil(t, booking.Transition(BOOKING_CONFIRMED))
+	require.Nil(t, booking.Transition(BOOKING_ACTIVE))
+	require.Nil(t, booking.Transition(BOOKING_COMPLETED))
+
+	require.NotNil(t, booking.Transition(BOOKING_CANCELLED))
+
+	// Terminal states
+	require.False(t, CanTransition(BOOKING_CANCELLED, BOOKING_CONFIRMED))
+	require.False(t, CanTransition(BOOKING_EXPIRED, BOOKING_ACTIVE))
+}
+
+func TestLegacyBookingState(t *testing.T) {
+	var completed, active Booking
+	require.Nil(t, json.Unmarshal([]byte(`{"bookingId":"a","is_completed":true}`), &completed))
+	require.Nil(t, json.Unmarshal([]byte(`{"bookingId":"b","is_completed":false}`), &active))
+
+	require.Equal(t, BOOKING_COMPLETED, completed.State)
+	require.Equal(t, BOOKING_ACTIVE, active.State)
+	require.Nil(t, active.Transition(BOOKING_COMPLETED))
+
+	// Recorded states are read as stored
+	cancelled := NewBooking("c", nil, "asset", 0, 10, 5, BOOKING_CANCELLED)
+	bz, err := json.Marshal(cancelled)
+	require.Nil(t, err)
+
+	var booking Booking
+	require.Nil(t, json.Unmarshal(bz, &booking))
+	require.Equal(t, BOOKING_CANCELLED, booking.State)
+	require.Equal(t, cancelled.EndTime, booking.EndTime)
+}
+
+func TestReputationAverage(t *testing.T) {
+	var r Reputation
+	require.True(t, r.Average().IsZero())
+
+	r = r.Add(5).Add(4)
+	require.True(t, r.Average().Equal(NewDecWithPrec(45, 1)))
+}
@@ -333,6 +333,35 @@ func (coins Coins) GetCoin(denom string) Coin {
 	panic(fmt.Sprintf("Coins %v don't have coin %s", coins, denom))
 }
 
+// AmountOf - amount of denom in coins, zero if coins has no such coin
+func (coins Coins) AmountOf(denom string) Dec {
+	if c, found := coins.Find(denom); found {
+		return c.Amount
+	}
+	return ZeroDec()
+}
+
+// Find - the coin of denom in coins, if any
+func (coins Coins) Find(denom string) (Coin, bool) {
+	for _, c := range coins {
+		if c.HasDenom(denom) {
+			return c, true
+		}
+	}
+	return Coin{}, false
+}
+
+// set - coins with the coin of c.Denom replaced by c, or c appended
+func (coins Coins) set(c Coin) Coins {
+	ret := make(Coins, 0, len(coins)+1)
+	for _, e := range coins {
+		if !e.IsSameDenom(c) {
+			ret = append(ret, e)
+		}
+	}
+	return append(ret, c)
+}
+
 //--------------------------------------------------------
 
 func IsValidDenom(denom string) bool {
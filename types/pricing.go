@@ -0,0 +1,106 @@
+package types
+
+import (
+	"fmt"
+	"math"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+// PricingModel - how bookings of an asset are charged. Every started Unit is
+// billed at Rate and the tier with the highest MinUnits reached is discounted.
+type PricingModel struct {
+	Denom    string         `json:"denom"`
+	Unit     int64          `json:"unit"`            // seconds per billed unit
+	Rate     Dec            `json:"rate"`            // price of one unit
+	MinUnits int64          `json:"min_units"`       // zero means no minimum
+	MaxUnits int64          `json:"max_units"`       // zero means no maximum
+	Tiers    []DiscountTier `json:"tiers,omitempty"` // sorted by MinUnits
+}
+
+// DiscountTier - Discount applies to bookings of at least MinUnits units
+type DiscountTier struct {
+	MinUnits int64 `json:"min_units"`
+	Discount Dec   `json:"discount"` // fraction of the price, 0.1 is 10%
+}
+
+func NewPricingModel(denom string, unit int64, rate Dec, minUnits int64, maxUnits int64, tiers []DiscountTier) PricingModel {
+	return PricingModel{
+		Denom:    denom,
+		Unit:     unit,
+		Rate:     rate,
+		MinUnits: minUnits,
+		MaxUnits: maxUnits,
+		Tiers:    tiers,
+	}
+}
+
+func NewDiscountTier(minUnits int64, discount Dec) DiscountTier {
+	return DiscountTier{
+		MinUnits: minUnits,
+		Discount: discount,
+	}
+}
+
+// IsValid - whether the model is well formed
+func (p PricingModel) IsValid() bool {
+	if !constants.DENOM_LIST[p.Denom] || p.Unit <= 0 {
+		return false
+	}
+
+	// The price of one unit must be an amount
+	if p.Rate.IsNil() || !p.Rate.IsNotNegative() || p.Rate.GT(NewDec(math.MaxInt64)) {
+		return false
+	}
+
+	if p.MinUnits < 0 || p.MaxUnits < 0 || (p.MaxUnits > 0 && p.MaxUnits < p.MinUnits) {
+		return false
+	}
+
+	var last int64
+	for _, t := range p.Tiers {
+		if t.MinUnits <= last || t.Discount.IsNil() {
+			return false
+		}
+		if !t.Discount.IsNotNegative() || !t.Discount.LT(OneDec()) {
+			return false
+		}
+		last = t.MinUnits
+	}
+
+	return true
+}
+
+// GetUnits - number of started units in [start, end)
+func (p PricingModel) GetUnits(start int64, end int64) int64 {
+	return (end - start + p.Unit - 1) / p.Unit
+}
+
+// AllowsUnits - whether a booking of units respects the duration limits
+func (p PricingModel) AllowsUnits(units int64) bool {
+	return units >= p.MinUnits && (p.MaxUnits == 0 || units <= p.MaxUnits)
+}
+
+// GetDiscount - discount of the highest tier reached by units
+func (p PricingModel) GetDiscount(units int64) Dec {
+	discount := ZeroDec()
+	for _, t := range p.Tiers {
+		if units < t.MinUnits {
+			break
+		}
+		discount = t.Discount
+	}
+	return discount
+}
+
+// GetPrice - price of units, rounded down to a whole amount of Denom. Prices
+// beyond the largest amount are refused.
+func (p PricingModel) GetPrice(units int64) (int64, sdk.Error) {
+	price := p.Rate.Mul(NewDec(units)).Mul(OneDec().Sub(p.GetDiscount(units))).TruncateInt()
+	if !price.IsInt64() {
+		return 0, sdk.ErrInvalidCoins(fmt.Sprintf(constants.BOOKING_PRICE_OVERFLOW, units, p.Rate))
+	}
+	return price.Int64(), nil
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+func TestPricingModelIsValid(t *testing.T) {
+	tiers := []DiscountTier{NewDiscountTier(7, NewDecWithPrec(1, 1)), NewDiscountTier(30, NewDecWithPrec(25, 2))}
+
+	valid := NewPricingModel(constants.BOOKING_DENOM, 3600, NewDec(10), 1, 0, tiers)
+	require.True(t, valid.IsValid())
+
+	invalid := []PricingModel{
+		NewPricingModel("BTC", 3600, NewDec(10), 0, 0, nil),
+		NewPricingModel(constants.BOOKING_DENOM, 0, NewDec(10), 0, 0, nil),
+		NewPricingModel(constants.BOOKING_DENOM, 3600, NewDec(-1), 0, 0, nil),
+		NewPricingModel(constants.BOOKING_DENOM, 3600, Dec{}, 0, 0, nil),
+		NewPricingModel(constants.BOOKING_DENOM, 3600, NewDec(10), 5, 2, nil),
+		NewPricingModel(constants.BOOKING_DENOM, 3600, NewDec(10), 0, 0,
+			[]DiscountTier{NewDiscountTier(30, NewDecWithPrec(1, 1)), NewDiscountTier(7, NewDecWithPrec(1, 1))}),
+		NewPricingModel(constants.BOOKING_DENOM, 3600, NewDec(10), 0, 0,
+			[]DiscountTier{NewDiscountTier(7, OneDec())}),
+	}
+	for _, p := range invalid {
+		require.False(t, p.IsValid(), "%v", p)
+	}
+}
+
+func TestPricingModelPrice(t *testing.T) {
+	tiers := []DiscountTier{NewDiscountTier(7, NewDecWithPrec(1, 1)), NewDiscountTier(30, NewDecWithPrec(25, 2))}
+	p := NewPricingModel(constants.BOOKING_DENOM, 3600, NewDecWithPrec(155, 1), 0, 0, tiers)
+
+	require.Equal(t, int64(2), p.GetUnits(0, 3601))
+
+	prices := map[int64]int64{
+		6:  93,  // 15.5 * 6
+		7:  97,  // 15.5 * 7 * 0.9 = 97.65
+		30: 348, // 15.5 * 30 * 0.75 = 348.75
+	}
+	for units, expected := range prices {
+		price, err := p.GetPrice(units)
+		require.Nil(t, err)
+		require.Equal(t, expected, price, "%d units", units)
+	}
+}
+
+func TestPricingModelPriceOverflow(t *testing.T) {
+	maxRate := NewDec(math.MaxInt64)
+	p := NewPricingModel(constants.BOOKING_DENOM, 3600, maxRate, 0, 0, nil)
+	require.True(t, p.IsValid())
+
+	price, err := p.GetPrice(1)
+	require.Nil(t, err)
+	require.Equal(t, int64(math.MaxInt64), price)
+
+	_, err = p.GetPrice(2)
+	require.NotNil(t, err)
+	_, err = p.GetPrice(math.MaxInt64)
+	require.NotNil(t, err)
+
+	// A rate above the largest amount is refused
+	p.Rate = maxRate.Add(OneDec())
+	require.False(t, p.IsValid())
+}
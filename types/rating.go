@@ -0,0 +1,44 @@
+package types
+
+import (
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+)
+
+// Rating - score given by one party of a completed booking to the other
+type Rating struct {
+	Rater      sdk.Address `json:"rater"`
+	Score      int64       `json:"score"`
+	ReviewHash []byte      `json:"review_hash,omitempty"` // hash of the off-chain review content
+	RatedAt    int64       `json:"rated_at"`              // unix time
+}
+
+func NewRating(rater sdk.Address, score int64, reviewHash []byte, ratedAt int64) Rating {
+	return Rating{
+		Rater:      rater,
+		Score:      score,
+		ReviewHash: reviewHash,
+		RatedAt:    ratedAt,
+	}
+}
+
+// Reputation - aggregate of the ratings received by an account or an asset
+type Reputation struct {
+	Count int64 `json:"count"`
+	Total int64 `json:"total"` // sum of scores
+}
+
+// Add - aggregate one more score
+func (r Reputation) Add(score int64) Reputation {
+	return Reputation{
+		Count: r.Count + 1,
+		Total: r.Total + score,
+	}
+}
+
+// Average - mean score, zero without ratings
+func (r Reputation) Average() Dec {
+	if r.Count == 0 {
+		return ZeroDec()
+	}
+	return NewDec(r.Total).Quo(NewDec(r.Count))
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"bytes"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+// Share - part of an asset held by Holder, out of ASSET_TOTAL_SHARES
+type Share struct {
+	Holder sdk.Address `json:"holder"`
+	Amount int64       `json:"amount"`
+}
+
+func NewShare(holder sdk.Address, amount int64) Share {
+	return Share{
+		Holder: holder,
+		Amount: amount,
+	}
+}
+
+// GetCapTable - holders of the asset. Assets whose shares were never
+// transferred are held entirely by Creator.
+func (a Asset) GetCapTable() []Share {
+	if len(a.Shares) == 0 {
+		return []Share{NewShare(a.Creator, constants.ASSET_TOTAL_SHARES)}
+	}
+	return a.Shares
+}
+
+// GetShares - number of shares held by addr
+func (a Asset) GetShares(addr sdk.Address) int64 {
+	for _, s := range a.GetCapTable() {
+		if bytes.Equal(s.Holder, addr) {
+			return s.Amount
+		}
+	}
+	return 0
+}
+
+// TransferShares - move amount shares from one holder to another. Holders
+// left without shares are removed. Returns false if from holds fewer shares.
+func (a *Asset) TransferShares(from sdk.Address, to sdk.Address, amount int64) bool {
+	if amount < 0 || a.GetShares(from) < amount {
+		return false
+	}
+	if amount == 0 || bytes.Equal(from, to) {
+		return true
+	}
+
+	table := []Share{}
+	received := false
+	for _, s := range a.GetCapTable() {
+		switch {
+		case bytes.Equal(s.Holder, from):
+			s.Amount -= amount
+		case bytes.Equal(s.Holder, to):
+			s.Amount += amount
+			received = true
+		}
+		if s.Amount > 0 {
+			table = append(table, s)
+		}
+	}
+	if !received {
+		table = append(table, NewShare(to, amount))
+	}
+
+	a.Shares = table
+	return true
+}
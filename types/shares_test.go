@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+func TestTransferShares(t *testing.T) {
+	ownerPub, _ := GenerateKeyPair()
+	holderPub, _ := GenerateKeyPair()
+	owner, holder := ownerPub.Address(), holderPub.Address()
+
+	asset := NewAsset("asset-1", owner, nil, true, 10)
+	require.Equal(t, constants.ASSET_TOTAL_SHARES, asset.GetShares(owner))
+
+	require.False(t, asset.TransferShares(holder, owner, 1))
+	require.False(t, asset.TransferShares(owner, holder, constants.ASSET_TOTAL_SHARES+1))
+
+	require.True(t, asset.TransferShares(owner, holder, 2500))
+	require.Equal(t, constants.ASSET_TOTAL_SHARES-2500, asset.GetShares(owner))
+	require.Equal(t, int64(2500), asset.GetShares(holder))
+
+	// Holders without shares leave the cap table
+	require.True(t, asset.TransferShares(holder, owner, 2500))
+	require.Len(t, asset.GetCapTable(), 1)
+	require.Equal(t, constants.ASSET_TOTAL_SHARES, asset.GetShares(owner))
+}
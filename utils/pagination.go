@@ -0,0 +1,35 @@
+package utils
+
+import (
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+// GetPageValues - values stored under prefix on a 1-based page of limit entries.
+// A zero page or limit falls back to the first page and QUERY_DEFAULT_LIMIT.
+func GetPageValues(store sdk.KVStore, prefix []byte, page int, limit int) (values [][]byte) {
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = constants.QUERY_DEFAULT_LIMIT
+	}
+	if limit > constants.QUERY_MAX_LIMIT {
+		limit = constants.QUERY_MAX_LIMIT
+	}
+
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	skip := (page - 1) * limit
+	for ; iterator.Valid() && len(values) < limit; iterator.Next() {
+		if skip > 0 {
+			skip--
+			continue
+		}
+		values = append(values, iterator.Value())
+	}
+
+	return values
+}
@@ -11,12 +11,11 @@ func ByteToString(inp []byte) string {
 	return  fmt.Sprintf("%x", inp)
 }
 
+// StringToAddress - decode a hex encoded address, panics on invalid hex
 func StringToAddress(input string) sdk.Address {
 	decoded, err := hex.DecodeString(input)
 	if err != nil {
 		panic(err)
 	}
-	var address sdk.Address
-	copy(address[:], decoded)
-	return address
+	return sdk.Address(decoded)
 }
\ No newline at end of file
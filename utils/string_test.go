@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+func TestStringToAddress(t *testing.T) {
+	addr := StringToAddress(constants.DEFAULT_RESERVE)
+	require.Len(t, addr, 20)
+	require.Equal(t, constants.DEFAULT_RESERVE, strings.ToUpper(ByteToString(addr)))
+
+	require.Len(t, StringToAddress(""), 0)
+	require.Panics(t, func() { StringToAddress("not hex") })
+}
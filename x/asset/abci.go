@@ -0,0 +1,16 @@
+package asset
+
+import (
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+// EndBlocker - prune asset changes which left the history retention window
+func EndBlocker(ctx sdk.Context, k Keeper) {
+	if pruned := k.PruneHistory(ctx, ctx.BlockHeight()); pruned > 0 {
+		constants.LOGGER.Info("Asset history pruned",
+			"Changes", pruned,
+		)
+	}
+}
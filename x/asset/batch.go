@@ -0,0 +1,125 @@
+package asset
+
+import (
+	"bytes"
+	"encoding/json"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/types"
+	msg "github.com/sharering/shareledger/x/asset/messages"
+)
+
+const (
+	BATCH_CREATE = "create"
+	BATCH_UPDATE = "update"
+)
+
+// BatchItemError - why an item of a MsgBatchAssets was refused
+type BatchItemError struct {
+	Action string `json:"action"` // BATCH_CREATE or BATCH_UPDATE
+	Index  int    `json:"index"`  // position in Creates or Updates
+	UUID   string `json:"uuid"`
+	Error  string `json:"error"`
+}
+
+// BatchAssets - create and update the assets of msg on behalf of signer. Every
+// item is checked before anything is written so that either all items are
+// applied or none is, and every failing item is reported.
+func (k Keeper) BatchAssets(ctx sdk.Context, msg msg.MsgBatchAssets, signer sdk.Address) ([]types.Asset, sdk.Error) {
+
+	store := ctx.KVStore(k.storeKey)
+	seen := make(map[string]bool)
+
+	var failures []BatchItemError
+	fail := func(action string, index int, uuid string, err sdk.Error) {
+		failures = append(failures, BatchItemError{action, index, uuid, err.Error()})
+	}
+
+	for i, c := range msg.Creates {
+		if err := c.ValidateBasic(); err != nil {
+			fail(BATCH_CREATE, i, c.UUID, err)
+			continue
+		}
+		if !bytes.Equal(c.Creator, signer) {
+			fail(BATCH_CREATE, i, c.UUID, ErrNotAssetOwner(k.codespace, signer, c.UUID))
+			continue
+		}
+		if seen[c.UUID] {
+			fail(BATCH_CREATE, i, c.UUID, ErrDuplicateInBatch(k.codespace, c.UUID))
+			continue
+		}
+		seen[c.UUID] = true
+
+		if store.Has([]byte(c.UUID)) {
+			fail(BATCH_CREATE, i, c.UUID, ErrAssetExists(k.codespace, c.UUID))
+			continue
+		}
+
+		asset := types.NewAsset(c.UUID, c.Creator, c.Hash, c.Status, c.Fee)
+		if err := k.checkArbiter(asset, c.Arbiter); err != nil {
+			fail(BATCH_CREATE, i, c.UUID, err)
+		}
+	}
+
+	for i, u := range msg.Updates {
+		if err := u.ValidateBasic(); err != nil {
+			fail(BATCH_UPDATE, i, u.UUID, err)
+			continue
+		}
+		if !bytes.Equal(u.Creator, signer) {
+			fail(BATCH_UPDATE, i, u.UUID, ErrNotAssetManager(k.codespace, signer, u.UUID))
+			continue
+		}
+		if seen[u.UUID] {
+			fail(BATCH_UPDATE, i, u.UUID, ErrDuplicateInBatch(k.codespace, u.UUID))
+			continue
+		}
+		seen[u.UUID] = true
+
+		asset, err := k.getAsset(ctx, u.UUID)
+		if err != nil {
+			fail(BATCH_UPDATE, i, u.UUID, err)
+			continue
+		}
+		if !asset.CanManage(signer) {
+			fail(BATCH_UPDATE, i, u.UUID, ErrNotAssetManager(k.codespace, signer, u.UUID))
+			continue
+		}
+
+		// Only the owner sets the arbiter, see UpdateAsset
+		if bytes.Equal(asset.Creator, signer) {
+			if err := k.checkArbiter(asset, u.Arbiter); err != nil {
+				fail(BATCH_UPDATE, i, u.UUID, err)
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		bz, err := json.Marshal(failures)
+		if err != nil {
+			return nil, ErrAssetEncoding(k.codespace)
+		}
+		return nil, ErrBatchFailed(k.codespace, string(bz))
+	}
+
+	assets := make([]types.Asset, 0, msg.Count())
+
+	for _, c := range msg.Creates {
+		asset, err := k.CreateAsset(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, asset)
+	}
+
+	for _, u := range msg.Updates {
+		asset, err := k.UpdateAsset(ctx, u, signer)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, asset)
+	}
+
+	return assets, nil
+}
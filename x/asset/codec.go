@@ -11,5 +11,10 @@ func RegisterCodec(cdc *wire.Codec) *wire.Codec {
 	cdc.RegisterConcrete(messages.MsgRetrieve{}, "shareledger/asset/MsgRetrieve", nil)
 	cdc.RegisterConcrete(messages.MsgUpdate{}, "shareledger/asset/MsgUpdate", nil)
 	cdc.RegisterConcrete(messages.MsgDelete{}, "shareledger/asset/MsgDelete", nil)
+	cdc.RegisterConcrete(messages.MsgTransferAsset{}, "shareledger/asset/MsgTransferAsset", nil)
+	cdc.RegisterConcrete(messages.MsgGrantOperator{}, "shareledger/asset/MsgGrantOperator", nil)
+	cdc.RegisterConcrete(messages.MsgRevokeOperator{}, "shareledger/asset/MsgRevokeOperator", nil)
+	cdc.RegisterConcrete(messages.MsgTransferShares{}, "shareledger/asset/MsgTransferShares", nil)
+	cdc.RegisterConcrete(messages.MsgBatchAssets{}, "shareledger/asset/MsgBatchAssets", nil)
 	return cdc
 }
\ No newline at end of file
@@ -0,0 +1,89 @@
+// nolint
+package asset
+
+import (
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+type CodeType = sdk.CodeType
+
+const (
+	DefaultCodespace sdk.CodespaceType = 6
+
+	CodeAssetNotFound  CodeType = 101
+	CodeAssetRented    CodeType = 102
+	CodeAssetEncoding  CodeType = 103
+	CodeOperatorExists CodeType = 104
+	CodeNoOperator     CodeType = 105
+	CodeNoShares       CodeType = 106
+	CodeAssetExists    CodeType = 107
+	CodeBatchFailed    CodeType = 108
+	CodeInvalidArbiter CodeType = 109
+	CodeArbiterLocked  CodeType = 110
+	CodeUnauthorized   CodeType = sdk.CodeUnauthorized
+	CodeInvalidAddress CodeType = sdk.CodeInvalidAddress
+)
+
+func ErrAssetNotFound(codespace sdk.CodespaceType, uuid string) sdk.Error {
+	return sdk.NewError(codespace, CodeAssetNotFound, fmt.Sprintf(constants.ERROR_STORE_NOT_FOUND, uuid, constants.STORE_ASSET))
+}
+
+func ErrAssetRented(codespace sdk.CodespaceType, uuid string) sdk.Error {
+	return sdk.NewError(codespace, CodeAssetRented, fmt.Sprintf(constants.ASSET_RENTED, uuid))
+}
+
+func ErrAssetEncoding(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeAssetEncoding, fmt.Sprintf(constants.ERROR_ENCODING, "types.Asset"))
+}
+
+func ErrAssetDecoding(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeAssetEncoding, fmt.Sprintf(constants.ERROR_DECODING, "types.Asset"))
+}
+
+func ErrNotAssetOwner(codespace sdk.CodespaceType, signer sdk.Address, uuid string) sdk.Error {
+	return sdk.NewError(codespace, CodeUnauthorized, fmt.Sprintf(constants.ASSET_NOT_OWNER, signer, uuid))
+}
+
+func ErrNotAssetManager(codespace sdk.CodespaceType, signer sdk.Address, uuid string) sdk.Error {
+	return sdk.NewError(codespace, CodeUnauthorized, fmt.Sprintf(constants.ASSET_NOT_MANAGER, signer, uuid))
+}
+
+func ErrOperatorExists(codespace sdk.CodespaceType, operator sdk.Address, uuid string) sdk.Error {
+	return sdk.NewError(codespace, CodeOperatorExists, fmt.Sprintf(constants.ASSET_OPERATOR_EXISTS, operator, uuid))
+}
+
+func ErrNoOperator(codespace sdk.CodespaceType, operator sdk.Address, uuid string) sdk.Error {
+	return sdk.NewError(codespace, CodeNoOperator, fmt.Sprintf(constants.ASSET_OPERATOR_NOT_FOUND, operator, uuid))
+}
+
+func ErrInsufficientShares(codespace sdk.CodespaceType, holder sdk.Address, held int64, uuid string, required int64) sdk.Error {
+	return sdk.NewError(codespace, CodeNoShares, fmt.Sprintf(constants.ASSET_INSUFFICIENT_SHARES, holder, held, uuid, required))
+}
+
+func ErrAssetExists(codespace sdk.CodespaceType, uuid string) sdk.Error {
+	return sdk.NewError(codespace, CodeAssetExists, fmt.Sprintf(constants.ASSET_EXISTS, uuid))
+}
+
+func ErrDuplicateInBatch(codespace sdk.CodespaceType, uuid string) sdk.Error {
+	return sdk.NewError(codespace, CodeBatchFailed, fmt.Sprintf(constants.ASSET_DUPLICATE_IN_BATCH, uuid))
+}
+
+func ErrBatchFailed(codespace sdk.CodespaceType, failures string) sdk.Error {
+	return sdk.NewError(codespace, CodeBatchFailed, fmt.Sprintf(constants.ASSET_BATCH_FAILED, failures))
+}
+
+func ErrInvalidArbiter(codespace sdk.CodespaceType, arbiter sdk.Address, uuid string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidArbiter, fmt.Sprintf(constants.ASSET_INVALID_ARBITER, arbiter, uuid))
+}
+
+func ErrArbiterLocked(codespace sdk.CodespaceType, uuid string, disputes int64) sdk.Error {
+	return sdk.NewError(codespace, CodeArbiterLocked, fmt.Sprintf(constants.ASSET_ARBITER_LOCKED, uuid, disputes))
+}
+
+func ErrMissingSigner(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAddress, constants.ASSET_MISSING_SIGNER)
+}
@@ -1,6 +1,7 @@
 package asset
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/sharering/shareledger/constants"
 	"github.com/sharering/shareledger/utils"
 	"github.com/sharering/shareledger/x/asset/messages"
+	"github.com/sharering/shareledger/x/auth"
 )
 
 func NewHandler(k Keeper) sdk.Handler {
@@ -28,6 +30,16 @@ func NewHandler(k Keeper) sdk.Handler {
 			return handleAssetUpdate(ctx, k, msg)
 		case messages.MsgDelete:
 			return handleAssetDelete(ctx, k, msg)
+		case messages.MsgTransferAsset:
+			return handleAssetTransfer(ctx, k, msg)
+		case messages.MsgGrantOperator:
+			return handleGrantOperator(ctx, k, msg)
+		case messages.MsgRevokeOperator:
+			return handleRevokeOperator(ctx, k, msg)
+		case messages.MsgTransferShares:
+			return handleTransferShares(ctx, k, msg)
+		case messages.MsgBatchAssets:
+			return handleBatchAssets(ctx, k, msg)
 
 		default:
 			errMsg := fmt.Sprintf("Unrecognized trace Msg type: %v", reflect.TypeOf(msg).Name())
@@ -38,9 +50,19 @@ func NewHandler(k Keeper) sdk.Handler {
 
 func handleAssetCreation(ctx sdk.Context, k Keeper, msg messages.MsgCreate) sdk.Result {
 
+	signer := auth.GetSigner(ctx)
+	if signer == nil {
+		return ErrMissingSigner(k.Codespace()).Result()
+	}
+
+	// Assets are only created in the name of the signer
+	if !bytes.Equal(msg.Creator, signer.GetAddress()) {
+		return ErrNotAssetOwner(k.Codespace(), signer.GetAddress(), msg.UUID).Result()
+	}
+
 	asset, err := k.CreateAsset(ctx, msg)
 	if err != nil {
-		return sdk.ErrInternal(err.Error()).Result()
+		return err.Result()
 	}
 
 	fee, denom := utils.GetMsgFee(msg)
@@ -57,7 +79,7 @@ func handleAssetRetrieval(ctx sdk.Context, k Keeper, msg messages.MsgRetrieve) s
 
 	asset, err := k.RetrieveAsset(ctx, msg)
 	if err != nil {
-		return sdk.ErrInternal(err.Error()).Result()
+		return err.Result()
 	}
 
 	return sdk.Result{
@@ -68,9 +90,14 @@ func handleAssetRetrieval(ctx sdk.Context, k Keeper, msg messages.MsgRetrieve) s
 
 func handleAssetUpdate(ctx sdk.Context, k Keeper, msg messages.MsgUpdate) sdk.Result {
 
-	asset, err := k.UpdateAsset(ctx, msg)
+	signer := auth.GetSigner(ctx)
+	if signer == nil {
+		return ErrMissingSigner(k.Codespace()).Result()
+	}
+
+	asset, err := k.UpdateAsset(ctx, msg, signer.GetAddress())
 	if err != nil {
-		return sdk.ErrInternal(err.Error()).Result()
+		return err.Result()
 	}
 
 	fee, denom := utils.GetMsgFee(msg)
@@ -85,9 +112,80 @@ func handleAssetUpdate(ctx sdk.Context, k Keeper, msg messages.MsgUpdate) sdk.Re
 
 func handleAssetDelete(ctx sdk.Context, k Keeper, msg messages.MsgDelete) sdk.Result {
 
-	asset, err := k.DeleteAsset(ctx, msg)
+	signer := auth.GetSigner(ctx)
+	if signer == nil {
+		return ErrMissingSigner(k.Codespace()).Result()
+	}
+
+	asset, err := k.DeleteAsset(ctx, msg, signer.GetAddress())
+	if err != nil {
+		return err.Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log:       fmt.Sprintf("%s", asset),
+		Tags:      msg.Tags(),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+func handleAssetTransfer(ctx sdk.Context, k Keeper, msg messages.MsgTransferAsset) sdk.Result {
+
+	signer := auth.GetSigner(ctx)
+	if signer == nil {
+		return ErrMissingSigner(k.Codespace()).Result()
+	}
+
+	asset, err := k.TransferAsset(ctx, msg, signer.GetAddress())
+	if err != nil {
+		return err.Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log:       fmt.Sprintf("%s", asset),
+		Tags:      msg.Tags().AppendTag("asset.previousOwner", []byte(signer.GetAddress().String())),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+func handleGrantOperator(ctx sdk.Context, k Keeper, msg messages.MsgGrantOperator) sdk.Result {
+
+	signer := auth.GetSigner(ctx)
+	if signer == nil {
+		return ErrMissingSigner(k.Codespace()).Result()
+	}
+
+	asset, err := k.GrantOperator(ctx, msg, signer.GetAddress())
+	if err != nil {
+		return err.Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log:       fmt.Sprintf("%s", asset),
+		Tags:      msg.Tags(),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+func handleRevokeOperator(ctx sdk.Context, k Keeper, msg messages.MsgRevokeOperator) sdk.Result {
+
+	signer := auth.GetSigner(ctx)
+	if signer == nil {
+		return ErrMissingSigner(k.Codespace()).Result()
+	}
+
+	asset, err := k.RevokeOperator(ctx, msg, signer.GetAddress())
 	if err != nil {
-		return sdk.ErrInternal(err.Error()).Result()
+		return err.Result()
 	}
 
 	fee, denom := utils.GetMsgFee(msg)
@@ -99,3 +197,55 @@ func handleAssetDelete(ctx sdk.Context, k Keeper, msg messages.MsgDelete) sdk.Re
 		FeeDenom:  denom,
 	}
 }
+
+func handleTransferShares(ctx sdk.Context, k Keeper, msg messages.MsgTransferShares) sdk.Result {
+
+	signer := auth.GetSigner(ctx)
+	if signer == nil {
+		return ErrMissingSigner(k.Codespace()).Result()
+	}
+
+	asset, err := k.TransferShares(ctx, msg, signer.GetAddress())
+	if err != nil {
+		return err.Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log:       fmt.Sprintf("%s", asset),
+		Tags:      msg.Tags().AppendTag("asset.shareSender", []byte(signer.GetAddress().String())),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+func handleBatchAssets(ctx sdk.Context, k Keeper, msg messages.MsgBatchAssets) sdk.Result {
+
+	signer := auth.GetSigner(ctx)
+	if signer == nil {
+		return ErrMissingSigner(k.Codespace()).Result()
+	}
+
+	assets, err := k.BatchAssets(ctx, msg, signer.GetAddress())
+	if err != nil {
+		return err.Result()
+	}
+
+	// Each item pays the fee of the equivalent single message
+	createFee, denom := utils.GetMsgFee(messages.MsgCreate{})
+	updateFee, _ := utils.GetMsgFee(messages.MsgUpdate{})
+	fee := createFee*int64(len(msg.Creates)) + updateFee*int64(len(msg.Updates))
+
+	uuids := make([]string, len(assets))
+	for i, asset := range assets {
+		uuids[i] = asset.UUID
+	}
+
+	return sdk.Result{
+		Log:       fmt.Sprintf("%v", uuids),
+		Tags:      msg.Tags(),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
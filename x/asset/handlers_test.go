@@ -0,0 +1,590 @@
+package asset
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/abci/types"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	"bitbucket.org/shareringvn/cosmos-sdk/store"
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"bitbucket.org/shareringvn/cosmos-sdk/wire"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/asset/messages"
+	"github.com/sharering/shareledger/x/auth"
+)
+
+var (
+	ownerPub, _    = types.GenerateKeyPair()
+	strangerPub, _ = types.GenerateKeyPair()
+	owner          = ownerPub.Address()
+	stranger       = strangerPub.Address()
+)
+
+func setupAssetTest(t *testing.T) (sdk.Context, Keeper, sdk.Handler) {
+	constants.LOGGER = log.NewNopLogger()
+
+	db := dbm.NewMemDB()
+	assetKey := sdk.NewKVStoreKey(constants.STORE_ASSET)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(assetKey, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	k := NewKeeper(assetKey, wire.NewCodec())
+
+	return ctx, k, NewHandler(k)
+}
+
+func withSigner(ctx sdk.Context, addr sdk.Address) sdk.Context {
+	return auth.WithSigners(ctx, auth.NewSHRAccountWithAddress(addr))
+}
+
+func createTestAsset(t *testing.T, ctx sdk.Context, handler sdk.Handler, status bool) {
+	msg := messages.NewMsgCreate(owner, []byte("hash"), "asset-1", status, 10)
+	res := handler(withSigner(ctx, owner), msg)
+	require.True(t, res.IsOK(), res.Log)
+}
+
+func TestUpdateAssetByOwner(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+	createTestAsset(t, ctx, handler, true)
+
+	// Creator in the message is ignored, owner stays the same
+	msg := messages.NewMsgUpdate(stranger, []byte("new-hash"), "asset-1", true, 20)
+	res := handler(withSigner(ctx, owner), msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	asset, err := k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	require.Equal(t, owner, asset.Creator)
+	require.Equal(t, int64(20), asset.Fee)
+}
+
+func TestUpdateAssetByNonOwner(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+	createTestAsset(t, ctx, handler, true)
+
+	msg := messages.NewMsgUpdate(stranger, []byte("new-hash"), "asset-1", true, 20)
+	res := handler(withSigner(ctx, stranger), msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnauthorized), res.Code)
+
+	asset, err := k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	require.Equal(t, owner, asset.Creator)
+	require.Equal(t, int64(10), asset.Fee)
+}
+
+func TestDeleteAsset(t *testing.T) {
+	ctx, _, handler := setupAssetTest(t)
+	createTestAsset(t, ctx, handler, true)
+
+	msg := messages.NewMsgDelete("asset-1")
+
+	res := handler(withSigner(ctx, stranger), msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnauthorized), res.Code)
+
+	res = handler(withSigner(ctx, owner), msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	res = handler(withSigner(ctx, owner), msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeAssetNotFound), res.Code)
+}
+
+func TestDeleteRentedAsset(t *testing.T) {
+	ctx, _, handler := setupAssetTest(t)
+
+	// Status false means the asset is being rented
+	createTestAsset(t, ctx, handler, false)
+
+	res := handler(withSigner(ctx, owner), messages.NewMsgDelete("asset-1"))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeAssetRented), res.Code)
+}
+
+func TestCreateExistingAsset(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+	createTestAsset(t, ctx, handler, true)
+
+	// Someone else cannot take over the UUID
+	msg := messages.NewMsgCreate(stranger, []byte("other-hash"), "asset-1", true, 1)
+	res := handler(withSigner(ctx, stranger), msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeAssetExists), res.Code)
+
+	asset, err := k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	require.Equal(t, owner, asset.Creator)
+	require.Equal(t, int64(10), asset.Fee)
+	require.Len(t, k.GetAssetsByOwner(ctx, stranger, 0, 0), 0)
+}
+
+func TestCreateAssetForAnother(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+
+	res := handler(withSigner(ctx, stranger), messages.NewMsgCreate(owner, []byte("hash"), "asset-1", true, 10))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnauthorized), res.Code)
+
+	res = handler(ctx, messages.NewMsgCreate(owner, []byte("hash"), "asset-1", true, 10))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidAddress), res.Code)
+
+	_, err := k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.NotNil(t, err)
+}
+
+func TestDeleteReservedAsset(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+	createTestAsset(t, ctx, handler, true)
+
+	// An upcoming booking keeps the asset available until it starts
+	asset, err := k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	asset.Calendar = []types.Reservation{types.NewReservation("booking-1", stranger, 100, 200)}
+	require.Nil(t, k.setAsset(ctx, asset))
+
+	res := handler(withSigner(ctx, owner), messages.NewMsgDelete("asset-1"))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeAssetRented), res.Code)
+
+	res = handler(withSigner(ctx, owner), messages.NewMsgTransferAsset("asset-1", stranger))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeAssetRented), res.Code)
+}
+
+func TestMissingSigner(t *testing.T) {
+	ctx, _, handler := setupAssetTest(t)
+	createTestAsset(t, ctx, handler, true)
+
+	res := handler(ctx, messages.NewMsgDelete("asset-1"))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidAddress), res.Code)
+}
+
+func TestTransferAsset(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+	createTestAsset(t, ctx, handler, true)
+
+	msg := messages.NewMsgTransferAsset("asset-1", stranger)
+
+	// Only the current owner can transfer
+	res := handler(withSigner(ctx, stranger), msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnauthorized), res.Code)
+
+	res = handler(withSigner(ctx, owner), msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	asset, err := k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	require.Equal(t, stranger, asset.Creator)
+
+	// Previous owner loses control of the asset
+	res = handler(withSigner(ctx, owner), messages.NewMsgDelete("asset-1"))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnauthorized), res.Code)
+}
+
+func TestTransferRentedAsset(t *testing.T) {
+	ctx, _, handler := setupAssetTest(t)
+	createTestAsset(t, ctx, handler, false)
+
+	res := handler(withSigner(ctx, owner), messages.NewMsgTransferAsset("asset-1", stranger))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeAssetRented), res.Code)
+}
+
+func TestAssetsByOwner(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+	createTestAsset(t, ctx, handler, true)
+
+	res := handler(withSigner(ctx, owner), messages.NewMsgCreate(owner, []byte("hash"), "asset-2", true, 10))
+	require.True(t, res.IsOK(), res.Log)
+
+	require.Len(t, k.GetAssetsByOwner(ctx, owner, 0, 0), 2)
+	require.Len(t, k.GetAssetsByOwner(ctx, owner, 1, 1), 1)
+	require.Len(t, k.GetAssetsByOwner(ctx, owner, 3, 1), 0)
+
+	// Transfers move the asset to the index of the new owner
+	res = handler(withSigner(ctx, owner), messages.NewMsgTransferAsset("asset-1", stranger))
+	require.True(t, res.IsOK(), res.Log)
+
+	assets := k.GetAssetsByOwner(ctx, stranger, 0, 0)
+	require.Len(t, assets, 1)
+	require.Equal(t, "asset-1", assets[0].UUID)
+	require.Len(t, k.GetAssetsByOwner(ctx, owner, 0, 0), 1)
+
+	res = handler(withSigner(ctx, owner), messages.NewMsgDelete("asset-2"))
+	require.True(t, res.IsOK(), res.Log)
+	require.Len(t, k.GetAssetsByOwner(ctx, owner, 0, 0), 0)
+}
+
+func TestAssetMetadataValidation(t *testing.T) {
+	msg := messages.NewMsgCreate(owner, []byte("hash"), "asset-1", true, 10)
+	msg.Metadata = types.NewAssetMetadata("car", "w3gv2", "Red bike", "ipfs://Qm", []byte("content"))
+	require.Nil(t, msg.ValidateBasic())
+
+	invalid := []types.AssetMetadata{
+		types.NewAssetMetadata("Car", "", "", "", nil),
+		types.NewAssetMetadata(strings.Repeat("a", constants.ASSET_MAX_CATEGORY_LENGTH+1), "", "", "", nil),
+		types.NewAssetMetadata("", "w3gva", "", "", nil),
+		types.NewAssetMetadata("", strings.Repeat("w", constants.ASSET_MAX_GEOHASH_LENGTH+1), "", "", nil),
+		types.NewAssetMetadata("", "", strings.Repeat("t", constants.ASSET_MAX_TITLE_LENGTH+1), "", nil),
+		types.NewAssetMetadata("", "", "", "ipfs://Qm", nil),
+	}
+	for _, m := range invalid {
+		msg.Metadata = m
+		require.NotNil(t, msg.ValidateBasic(), "%v", m)
+	}
+}
+
+func TestAssetUUIDValidation(t *testing.T) {
+	ctx, _, handler := setupAssetTest(t)
+
+	require.Nil(t, messages.NewMsgCreate(owner, []byte("hash"), "3f2b-Asset_1", true, 10).ValidateBasic())
+
+	// UUIDs cannot reach into the index prefixes of the store
+	invalid := []string{"", "\x01" + string(owner), "asset 1", "asset/1", strings.Repeat("a", constants.ASSET_MAX_UUID_LENGTH+1)}
+	for _, uuid := range invalid {
+		require.NotNil(t, messages.NewMsgCreate(owner, []byte("hash"), uuid, true, 10).ValidateBasic(), "%q", uuid)
+		require.NotNil(t, messages.NewMsgUpdate(owner, []byte("hash"), uuid, true, 10).ValidateBasic(), "%q", uuid)
+	}
+
+	creates := []messages.MsgCreate{messages.NewMsgCreate(owner, []byte("hash"), "\x06", true, 10)}
+	res := handler(withSigner(ctx, owner), messages.NewMsgBatchAssets(creates, nil))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeBatchFailed), res.Code)
+}
+
+func TestAssetsByCategoryAndLocation(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+
+	create := func(uuid string, category string, geohash string) {
+		msg := messages.NewMsgCreate(owner, []byte("hash"), uuid, true, 10)
+		msg.Metadata = types.NewAssetMetadata(category, geohash, "", "", nil)
+		res := handler(withSigner(ctx, owner), msg)
+		require.True(t, res.IsOK(), res.Log)
+	}
+	create("asset-1", "car", "w3gv2c")
+	create("asset-2", "car", "w3gv9")
+	create("asset-3", "cars", "u4pru")
+
+	// Categories match exactly
+	require.Len(t, k.GetAssetsByCategory(ctx, "car", 0, 0), 2)
+	require.Len(t, k.GetAssetsByCategory(ctx, "cars", 0, 0), 1)
+
+	// Geohash cells include every asset located inside them
+	require.Len(t, k.GetAssetsByLocation(ctx, "w3gv", 0, 0), 2)
+	require.Len(t, k.GetAssetsByLocation(ctx, "w3gv2", 0, 0), 1)
+	require.Len(t, k.GetAssetsByLocation(ctx, "u", 0, 0), 1)
+
+	// Updates move the asset between indexes
+	msg := messages.NewMsgUpdate(owner, []byte("hash"), "asset-1", true, 10)
+	msg.Metadata = types.NewAssetMetadata("bike", "u4pr", "", "", nil)
+	res := handler(withSigner(ctx, owner), msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	require.Len(t, k.GetAssetsByCategory(ctx, "car", 0, 0), 1)
+	require.Len(t, k.GetAssetsByCategory(ctx, "bike", 0, 0), 1)
+	require.Len(t, k.GetAssetsByLocation(ctx, "w3gv", 0, 0), 1)
+	require.Len(t, k.GetAssetsByLocation(ctx, "u4pr", 0, 0), 2)
+
+	res = handler(withSigner(ctx, owner), messages.NewMsgDelete("asset-1"))
+	require.True(t, res.IsOK(), res.Log)
+	require.Len(t, k.GetAssetsByCategory(ctx, "bike", 0, 0), 0)
+	require.Len(t, k.GetAssetsByLocation(ctx, "u4pr", 0, 0), 1)
+}
+
+func TestAssetOperators(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+	createTestAsset(t, ctx, handler, true)
+
+	operatorPub, _ := types.GenerateKeyPair()
+	operator := operatorPub.Address()
+
+	// Only the owner grants rights, once
+	res := handler(withSigner(ctx, stranger), messages.NewMsgGrantOperator("asset-1", operator))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnauthorized), res.Code)
+	res = handler(withSigner(ctx, owner), messages.NewMsgGrantOperator("asset-1", operator))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(withSigner(ctx, owner), messages.NewMsgGrantOperator("asset-1", operator))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeOperatorExists), res.Code)
+
+	// Operators change fee, pricing and metadata only
+	msg := messages.NewMsgUpdate(operator, []byte("new-hash"), "asset-1", true, 20)
+	msg.Deposit = 500
+	msg.Metadata = types.NewAssetMetadata("car", "", "", "", nil)
+	res = handler(withSigner(ctx, operator), msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	asset, err := k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	require.Equal(t, int64(20), asset.Fee)
+	require.Equal(t, "car", asset.Metadata.Category)
+	require.Equal(t, int64(0), asset.Deposit)
+	require.Equal(t, []byte("hash"), asset.Hash)
+	require.Equal(t, owner, asset.Creator)
+	require.Len(t, asset.Operators, 1)
+
+	// Operators cannot transfer or delete
+	res = handler(withSigner(ctx, operator), messages.NewMsgTransferAsset("asset-1", operator))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnauthorized), res.Code)
+	res = handler(withSigner(ctx, operator), messages.NewMsgDelete("asset-1"))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnauthorized), res.Code)
+
+	res = handler(withSigner(ctx, owner), messages.NewMsgRevokeOperator("asset-1", operator))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(withSigner(ctx, owner), messages.NewMsgRevokeOperator("asset-1", operator))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoOperator), res.Code)
+
+	res = handler(withSigner(ctx, operator), msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnauthorized), res.Code)
+}
+
+func TestAssetArbiter(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+
+	// The owner cannot arbitrate its own asset
+	msg := messages.NewMsgCreate(owner, []byte("hash"), "asset-1", true, 10)
+	msg.Arbiter = owner
+	require.NotNil(t, msg.ValidateBasic())
+	_, err := k.CreateAsset(ctx, msg)
+	require.Equal(t, CodeInvalidArbiter, err.Code())
+
+	createTestAsset(t, ctx, handler, true)
+
+	operatorPub, _ := types.GenerateKeyPair()
+	operator := operatorPub.Address()
+	res := handler(withSigner(ctx, owner), messages.NewMsgGrantOperator("asset-1", operator))
+	require.True(t, res.IsOK(), res.Log)
+
+	// Nor can an operator
+	update := messages.NewMsgUpdate(owner, []byte("hash"), "asset-1", true, 10)
+	update.Arbiter = operator
+	res = handler(withSigner(ctx, owner), update)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidArbiter), res.Code)
+
+	// Nor a renter holding a reservation
+	renterPub, _ := types.GenerateKeyPair()
+	renter := renterPub.Address()
+	asset, err := k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	asset.Calendar = []types.Reservation{types.NewReservation("booking-1", renter, 100, 200)}
+	require.Nil(t, k.setAsset(ctx, asset))
+
+	update.Arbiter = renter
+	res = handler(withSigner(ctx, owner), update)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidArbiter), res.Code)
+
+	update.Arbiter = stranger
+	res = handler(withSigner(ctx, owner), update)
+	require.True(t, res.IsOK(), res.Log)
+
+	// The arbiter cannot become an operator
+	res = handler(withSigner(ctx, owner), messages.NewMsgGrantOperator("asset-1", stranger))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidArbiter), res.Code)
+
+	// The arbiter stays while a dispute is open
+	asset, err = k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	asset.OpenDisputes = 1
+	require.Nil(t, k.setAsset(ctx, asset))
+
+	update.Arbiter = nil
+	res = handler(withSigner(ctx, owner), update)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeArbiterLocked), res.Code)
+
+	update.Arbiter = stranger
+	update.Fee = 20
+	res = handler(withSigner(ctx, owner), update)
+	require.True(t, res.IsOK(), res.Log)
+
+	asset, err = k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	require.Equal(t, stranger, asset.Arbiter)
+	require.Equal(t, int64(1), asset.OpenDisputes)
+}
+
+func TestTransferShares(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+	createTestAsset(t, ctx, handler, true)
+
+	res := handler(withSigner(ctx, stranger), messages.NewMsgTransferShares("asset-1", owner, 1))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoShares), res.Code)
+
+	res = handler(withSigner(ctx, owner), messages.NewMsgTransferShares("asset-1", stranger, 4000))
+	require.True(t, res.IsOK(), res.Log)
+
+	asset, err := k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	require.Equal(t, int64(6000), asset.GetShares(owner))
+	require.Equal(t, int64(4000), asset.GetShares(stranger))
+
+	// Updates by the owner keep the cap table
+	res = handler(withSigner(ctx, owner), messages.NewMsgUpdate(owner, []byte("hash"), "asset-1", true, 20))
+	require.True(t, res.IsOK(), res.Log)
+	asset, err = k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	require.Equal(t, int64(4000), asset.GetShares(stranger))
+
+	// Shareholders do not manage the asset
+	res = handler(withSigner(ctx, stranger), messages.NewMsgDelete("asset-1"))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnauthorized), res.Code)
+
+	// Transferring the asset moves the shares of the owner only
+	newOwnerPub, _ := types.GenerateKeyPair()
+	newOwner := newOwnerPub.Address()
+	res = handler(withSigner(ctx, owner), messages.NewMsgTransferAsset("asset-1", newOwner))
+	require.True(t, res.IsOK(), res.Log)
+
+	asset, err = k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	require.Equal(t, int64(0), asset.GetShares(owner))
+	require.Equal(t, int64(6000), asset.GetShares(newOwner))
+	require.Equal(t, int64(4000), asset.GetShares(stranger))
+
+	cdc := wire.NewCodec()
+	params, errRes := cdc.MarshalBinary(QueryAssetParams{UUID: "asset-1"})
+	require.Nil(t, errRes)
+	bz, qErr := NewQuerier(k, cdc)(ctx, []string{QueryCapTable}, abci.RequestQuery{Data: params})
+	require.Nil(t, qErr)
+
+	var table QueryCapTableResult
+	require.Nil(t, cdc.UnmarshalJSON(bz, &table))
+	require.Equal(t, newOwner, table.Owner)
+	require.Len(t, table.Shares, 2)
+}
+
+func TestBatchAssets(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+	createTestAsset(t, ctx, handler, true)
+
+	creates := []messages.MsgCreate{
+		messages.NewMsgCreate(owner, []byte("hash"), "asset-2", true, 10),
+		messages.NewMsgCreate(owner, []byte("hash"), "asset-3", true, 10),
+	}
+	updates := []messages.MsgUpdate{
+		messages.NewMsgUpdate(owner, []byte("new-hash"), "asset-1", true, 20),
+	}
+
+	res := handler(withSigner(ctx, owner), messages.NewMsgBatchAssets(creates, updates))
+	require.True(t, res.IsOK(), res.Log)
+	createFee, _ := utils.GetMsgFee(messages.MsgCreate{})
+	updateFee, _ := utils.GetMsgFee(messages.MsgUpdate{})
+	require.Equal(t, 2*createFee+updateFee, res.FeeAmount)
+
+	require.Len(t, k.GetAssetsByOwner(ctx, owner, 1, 0), 3)
+	asset, err := k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	require.Equal(t, int64(20), asset.Fee)
+
+	// Every failing item is reported and nothing is written
+	creates = []messages.MsgCreate{
+		messages.NewMsgCreate(owner, []byte("hash"), "asset-4", true, 10),
+		messages.NewMsgCreate(owner, []byte("hash"), "asset-2", true, 10),
+		messages.NewMsgCreate(stranger, []byte("hash"), "asset-5", true, 10),
+	}
+	updates = []messages.MsgUpdate{
+		messages.NewMsgUpdate(owner, []byte("hash"), "asset-4", true, 30),
+		messages.NewMsgUpdate(owner, []byte("hash"), "missing", true, 30),
+	}
+
+	res = handler(withSigner(ctx, owner), messages.NewMsgBatchAssets(creates, updates))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeBatchFailed), res.Code)
+	for _, uuid := range []string{"asset-2", "asset-5", "asset-4", "missing"} {
+		require.Contains(t, res.Log, uuid)
+	}
+
+	_, err = k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-4"))
+	require.NotNil(t, err)
+	require.Len(t, k.GetAssetsByOwner(ctx, owner, 1, 0), 3)
+
+	// Every item creator signs the batch once
+	signers := messages.NewMsgBatchAssets(creates, updates).GetSigners()
+	require.Equal(t, []sdk.Address{owner, stranger}, signers)
+
+	// Batch size is bounded
+	require.NotNil(t, messages.NewMsgBatchAssets(nil, nil).ValidateBasic())
+	creates = make([]messages.MsgCreate, constants.ASSET_MAX_BATCH_SIZE+1)
+	require.NotNil(t, messages.NewMsgBatchAssets(creates, nil).ValidateBasic())
+}
+
+func TestBatchAssetsArbiter(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+	createTestAsset(t, ctx, handler, true)
+
+	res := handler(withSigner(ctx, owner), messages.NewMsgGrantOperator("asset-1", stranger))
+	require.True(t, res.IsOK(), res.Log)
+
+	// Items are checked like single updates before anything is written
+	creates := []messages.MsgCreate{messages.NewMsgCreate(owner, []byte("hash"), "asset-2", true, 10)}
+	update := messages.NewMsgUpdate(owner, []byte("hash"), "asset-1", true, 20)
+	update.Arbiter = stranger
+
+	res = handler(withSigner(ctx, owner), messages.NewMsgBatchAssets(creates, []messages.MsgUpdate{update}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeBatchFailed), res.Code)
+	require.Contains(t, res.Log, "cannot arbitrate")
+	_, err := k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-2"))
+	require.NotNil(t, err)
+
+	// Nor is the arbiter replaced while a dispute is open
+	arbiterPub, _ := types.GenerateKeyPair()
+	asset, err := k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-1"))
+	require.Nil(t, err)
+	asset.Arbiter = arbiterPub.Address()
+	asset.OpenDisputes = 1
+	require.Nil(t, k.setAsset(ctx, asset))
+
+	update.Arbiter = nil
+	res = handler(withSigner(ctx, owner), messages.NewMsgBatchAssets(creates, []messages.MsgUpdate{update}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeBatchFailed), res.Code)
+	require.Contains(t, res.Log, "cannot change while")
+	_, err = k.RetrieveAsset(ctx, messages.NewMsgRetrieve("asset-2"))
+	require.NotNil(t, err)
+
+	update.Arbiter = asset.Arbiter
+	res = handler(withSigner(ctx, owner), messages.NewMsgBatchAssets(creates, []messages.MsgUpdate{update}))
+	require.True(t, res.IsOK(), res.Log)
+}
+
+func TestAssetHistory(t *testing.T) {
+	ctx, k, handler := setupAssetTest(t)
+	ctx = ctx.WithBlockHeight(10)
+	createTestAsset(t, ctx, handler, true)
+
+	ctx = ctx.WithBlockHeight(20)
+	update := messages.NewMsgUpdate(owner, []byte("hash"), "asset-1", true, 20)
+	res := handler(withSigner(ctx, owner), update)
+	require.True(t, res.IsOK(), res.Log)
+
+	res = handler(withSigner(ctx, owner), messages.NewMsgGrantOperator("asset-1", stranger))
+	require.True(t, res.IsOK(), res.Log)
+
+	ctx = ctx.WithBlockHeight(30)
+	res = handler(withSigner(ctx, owner), messages.NewMsgDelete("asset-1"))
+	require.True(t, res.IsOK(), res.Log)
+
+	// History outlives the asset
+	history := k.GetHistory(ctx, "asset-1", 1, 0)
+	require.Len(t, history, 4)
+	require.Equal(t, HISTORY_CREATE, history[0].Action)
+	require.Equal(t, int64(10), history[0].Height)
+	require.Equal(t, owner, history[0].Signer)
+	require.Equal(t, HISTORY_UPDATE, history[1].Action)
+	require.Equal(t, []string{"fee"}, history[1].Fields)
+	require.Equal(t, HISTORY_GRANT_OPERATOR, history[2].Action)
+	require.Equal(t, []string{"operators"}, history[2].Fields)
+	require.Equal(t, HISTORY_DELETE, history[3].Action)
+	require.Len(t, k.GetHistory(ctx, "asset-1", 2, 3), 1)
+
+	// Nothing is pruned without a retention window
+	require.Equal(t, 0, k.PruneHistory(ctx, 1000))
+
+	retention := constants.ASSET_HISTORY_RETENTION
+	constants.ASSET_HISTORY_RETENTION = 15
+	defer func() { constants.ASSET_HISTORY_RETENTION = retention }()
+
+	require.Equal(t, 0, k.PruneHistory(ctx, 24))
+	require.Equal(t, 1, k.PruneHistory(ctx, 25))
+	require.Len(t, k.GetHistory(ctx, "asset-1", 1, 0), 3)
+
+	EndBlocker(ctx.WithBlockHeight(45), k)
+	require.Len(t, k.GetHistory(ctx, "asset-1", 1, 0), 0)
+}
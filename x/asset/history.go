@@ -0,0 +1,158 @@
+package asset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+)
+
+// actions recorded in the history of an asset
+const (
+	HISTORY_CREATE          = "create"
+	HISTORY_UPDATE          = "update"
+	HISTORY_DELETE          = "delete"
+	HISTORY_TRANSFER        = "transfer"
+	HISTORY_GRANT_OPERATOR  = "grant_operator"
+	HISTORY_REVOKE_OPERATOR = "revoke_operator"
+	HISTORY_TRANSFER_SHARES = "transfer_shares"
+)
+
+// AssetChange - one entry of the append-only history of an asset. Entries
+// outlive the asset itself and are pruned ASSET_HISTORY_RETENTION blocks
+// after they were recorded.
+type AssetChange struct {
+	UUID   string      `json:"uuid"`
+	Height int64       `json:"height"`
+	Time   int64       `json:"time"` // unix time
+	Signer sdk.Address `json:"signer"`
+	Action string      `json:"action"`
+	Fields []string    `json:"fields"` // json names of the fields whose value changed
+}
+
+// recordChange - append the change made by signer to the history of the asset.
+// Must be called before after is written, the stored asset is the state before
+// the change.
+func (k Keeper) recordChange(ctx sdk.Context, action string, signer sdk.Address, after types.Asset) {
+	store := ctx.KVStore(k.storeKey)
+
+	// Nothing is stored yet for a new asset
+	before, _ := k.getAsset(ctx, after.UUID)
+
+	change := AssetChange{
+		UUID:   after.UUID,
+		Height: ctx.BlockHeight(),
+		Time:   ctx.BlockHeader().Time,
+		Signer: signer,
+		Action: action,
+		Fields: changedFields(before, after),
+	}
+
+	bz, err := json.Marshal(change)
+	if err != nil {
+		constants.LOGGER.Error("Asset change not recorded",
+			"UUID", change.UUID,
+			"Error", err.Error(),
+		)
+		return
+	}
+
+	sequence := k.getHistorySequence(ctx)
+	key := GetHistoryKey(change.UUID, sequence)
+
+	store.Set(key, bz)
+	store.Set(GetHistoryQueueKey(change.Height, sequence), key)
+	k.setHistorySequence(ctx, sequence+1)
+}
+
+// GetHistory - one page of the changes of an asset, oldest first
+func (k Keeper) GetHistory(ctx sdk.Context, uuid string, page int, limit int) []AssetChange {
+	changes := []AssetChange{}
+
+	for _, bz := range utils.GetPageValues(ctx.KVStore(k.storeKey), GetHistoryPrefix(uuid), page, limit) {
+		var change AssetChange
+		if err := json.Unmarshal(bz, &change); err == nil {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// PruneHistory - delete changes recorded more than ASSET_HISTORY_RETENTION
+// blocks before height, up to ASSET_HISTORY_PRUNE_LIMIT per call. A retention
+// of zero keeps the history forever. Returns the number of pruned changes.
+func (k Keeper) PruneHistory(ctx sdk.Context, height int64) int {
+	if constants.ASSET_HISTORY_RETENTION <= 0 {
+		return 0
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	cutoff := height - constants.ASSET_HISTORY_RETENTION
+
+	iterator := sdk.KVStorePrefixIterator(store, HistoryQueueKey)
+
+	var queueKeys, historyKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		if getHistoryQueueHeight(iterator.Key()) > cutoff || len(queueKeys) >= constants.ASSET_HISTORY_PRUNE_LIMIT {
+			break
+		}
+		queueKeys = append(queueKeys, iterator.Key())
+		historyKeys = append(historyKeys, iterator.Value())
+	}
+	iterator.Close()
+
+	for i := range queueKeys {
+		store.Delete(queueKeys[i])
+		store.Delete(historyKeys[i])
+	}
+
+	return len(queueKeys)
+}
+
+func (k Keeper) getHistorySequence(ctx sdk.Context) int64 {
+	bz := ctx.KVStore(k.storeKey).Get(HistorySeqKey)
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+func (k Keeper) setHistorySequence(ctx sdk.Context, sequence int64) {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(sequence))
+	ctx.KVStore(k.storeKey).Set(HistorySeqKey, bz)
+}
+
+// changedFields - json names of the fields of an asset which differ between
+// before and after, sorted
+func changedFields(before types.Asset, after types.Asset) []string {
+	var a, b map[string]json.RawMessage
+
+	bzA, errA := json.Marshal(before)
+	bzB, errB := json.Marshal(after)
+	if errA != nil || errB != nil || json.Unmarshal(bzA, &a) != nil || json.Unmarshal(bzB, &b) != nil {
+		return nil
+	}
+
+	fields := []string{}
+	for name, value := range b {
+		if !bytes.Equal(a[name], value) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range a {
+		if _, found := b[name]; !found {
+			fields = append(fields, name)
+		}
+	}
+
+	sort.Strings(fields)
+	return fields
+}
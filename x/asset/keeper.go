@@ -1,126 +1,371 @@
 package asset
 
 import (
-	"errors"
+	"bytes"
 	"encoding/json"
 
 	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
 	"bitbucket.org/shareringvn/cosmos-sdk/wire"
 
 	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
 	msg "github.com/sharering/shareledger/x/asset/messages"
 )
 
 // Keeper data type
 type Keeper struct {
-	storeKey sdk.StoreKey // key used to access the store from the Context.
-	cdc      *wire.Codec
+	storeKey  sdk.StoreKey // key used to access the store from the Context.
+	cdc       *wire.Codec
+	codespace sdk.CodespaceType
 }
 
 // NewKeeper - Returns the Keeper
 func NewKeeper(key sdk.StoreKey, cdc *wire.Codec) Keeper {
 	return Keeper{
-		storeKey: key,
-		cdc:      cdc,
+		storeKey:  key,
+		cdc:       cdc,
+		codespace: DefaultCodespace,
 	}
 }
 
-//----------------------------------------------------------
-func (k Keeper) CreateAsset(ctx sdk.Context, msg msg.MsgCreate) (types.Asset, error) {
+// Codespace - return the codespace of errors raised by this keeper
+func (k Keeper) Codespace() sdk.CodespaceType {
+	return k.codespace
+}
+
+// ----------------------------------------------------------
+
+// CreateAsset - store a new asset. An existing UUID is refused so that nobody
+// can take over the asset of someone else.
+func (k Keeper) CreateAsset(ctx sdk.Context, msg msg.MsgCreate) (types.Asset, sdk.Error) {
 
 	store := ctx.KVStore(k.storeKey)
 
+	if store.Has([]byte(msg.UUID)) {
+		return types.Asset{}, ErrAssetExists(k.codespace, msg.UUID)
+	}
+
 	asset := types.NewAsset(msg.UUID, msg.Creator, msg.Hash, msg.Status, msg.Fee)
+	if err := k.checkArbiter(asset, msg.Arbiter); err != nil {
+		return types.Asset{}, err
+	}
 
+	asset.Deposit = msg.Deposit
+	asset.Refund = msg.Refund
+	asset.Arbiter = msg.Arbiter
+	asset.ApprovalRequired = msg.ApprovalRequired
+	asset.LateFee = msg.LateFee
+	asset.EarlyReturn = msg.EarlyReturn
+	asset.Metadata = msg.Metadata
+	asset.Pricing = msg.Pricing
 
 	assetBytes, err := json.Marshal(asset)
 
 	if err != nil {
-		return types.Asset{}, errors.New("Asset Encoding Error")
-
+		return types.Asset{}, ErrAssetEncoding(k.codespace)
 	}
 
+	k.recordChange(ctx, HISTORY_CREATE, msg.Creator, asset)
+
 	// Store to KVStore
 	store.Set([]byte(msg.UUID), assetBytes)
+	setIndexes(store, asset)
 
 	return asset, nil
 }
 
-func (k Keeper) RetrieveAsset(ctx sdk.Context, msg msg.MsgRetrieve) (types.Asset, error) {
+func (k Keeper) RetrieveAsset(ctx sdk.Context, msg msg.MsgRetrieve) (types.Asset, sdk.Error) {
+	return k.getAsset(ctx, msg.UUID)
+}
+
+// UpdateAsset - update an asset on behalf of signer. The owner recorded in the
+// store updates every field, operators only the fee, pricing and metadata.
+// Ownership and operators are never changed here.
+func (k Keeper) UpdateAsset(ctx sdk.Context, msg msg.MsgUpdate, signer sdk.Address) (types.Asset, sdk.Error) {
+
+	asset, err := k.getAsset(ctx, msg.UUID)
+	if err != nil {
+		return types.Asset{}, err
+	}
+
+	if !asset.CanManage(signer) {
+		return types.Asset{}, ErrNotAssetManager(k.codespace, signer, msg.UUID)
+	}
+
+	updated := asset
+	if bytes.Equal(asset.Creator, signer) {
+		if err := k.checkArbiter(asset, msg.Arbiter); err != nil {
+			return types.Asset{}, err
+		}
+
+		updated = types.NewAsset(msg.UUID, asset.Creator, msg.Hash, msg.Status, msg.Fee)
+		updated.Deposit = msg.Deposit
+		updated.Refund = msg.Refund
+		updated.Arbiter = msg.Arbiter
+		updated.ApprovalRequired = msg.ApprovalRequired
+		updated.LateFee = msg.LateFee
+		updated.EarlyReturn = msg.EarlyReturn
+		updated.Operators = asset.Operators
+		updated.Shares = asset.Shares
+	}
+	updated.Fee = msg.Fee
+	updated.Metadata = msg.Metadata
+	updated.Pricing = msg.Pricing
+
+	// Reservations and disputes are managed by the booking module and stay
+	// untouched
+	updated.Calendar = asset.Calendar
+	updated.OpenDisputes = asset.OpenDisputes
+	if len(asset.Calendar) > 0 {
+		updated.Status = asset.Status
+	}
 
 	store := ctx.KVStore(k.storeKey)
+	deleteIndexes(store, asset)
 
-	assetBytes := store.Get([]byte(msg.UUID))
+	asset = updated
 
-	if assetBytes == nil {
-		return types.Asset{}, errors.New("Asset is not found")
+	k.recordChange(ctx, HISTORY_UPDATE, signer, asset)
+	if err := k.setAsset(ctx, asset); err != nil {
+		return types.Asset{}, err
 	}
+	setIndexes(store, asset)
 
-	var asset types.Asset
+	return asset, nil
+}
+
+// checkArbiter - whether arbiter may replace the arbiter of asset. Arbiters
+// must not be a party of the disputes they resolve: the owner, an operator or
+// a renter holding a reservation. The arbiter cannot change while a dispute is
+// open.
+func (k Keeper) checkArbiter(asset types.Asset, arbiter sdk.Address) sdk.Error {
+	if len(arbiter) > 0 && (asset.CanManage(arbiter) || asset.IsRenter(arbiter)) {
+		return ErrInvalidArbiter(k.codespace, arbiter, asset.UUID)
+	}
+	if !bytes.Equal(arbiter, asset.Arbiter) && asset.OpenDisputes > 0 {
+		return ErrArbiterLocked(k.codespace, asset.UUID, asset.OpenDisputes)
+	}
+	return nil
+}
+
+// DeleteAsset - delete an asset on behalf of signer. Rented or reserved assets
+// cannot be deleted until every booking is completed or cancelled.
+func (k Keeper) DeleteAsset(ctx sdk.Context, msg msg.MsgDelete, signer sdk.Address) (types.Asset, sdk.Error) {
 
-	derr := json.Unmarshal(assetBytes, &asset)
-	if derr != nil {
-		return types.Asset{}, errors.New("Asset decoding error")
+	asset, err := k.getAsset(ctx, msg.UUID)
+	if err != nil {
+		return types.Asset{}, err
 	}
 
+	if !bytes.Equal(asset.Creator, signer) {
+		return types.Asset{}, ErrNotAssetOwner(k.codespace, signer, msg.UUID)
+	}
+
+	// Status false means the asset is being rented, the calendar holds the
+	// upcoming reservations
+	if !asset.Status || len(asset.Calendar) > 0 {
+		return types.Asset{}, ErrAssetRented(k.codespace, msg.UUID)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+
+	// Delete asset, its history is kept
+	k.recordChange(ctx, HISTORY_DELETE, signer, asset)
+	store.Delete([]byte(msg.UUID))
+	deleteIndexes(store, asset)
 
 	return asset, nil
 }
 
+// TransferAsset - move ownership of an asset from signer to msg.NewOwner.
+// Transfers are refused while the asset is rented or reserved.
+func (k Keeper) TransferAsset(ctx sdk.Context, msg msg.MsgTransferAsset, signer sdk.Address) (types.Asset, sdk.Error) {
+
+	asset, err := k.getAsset(ctx, msg.UUID)
+	if err != nil {
+		return types.Asset{}, err
+	}
+
+	if !bytes.Equal(asset.Creator, signer) {
+		return types.Asset{}, ErrNotAssetOwner(k.codespace, signer, msg.UUID)
+	}
 
-func (k Keeper) UpdateAsset(ctx sdk.Context, msg msg.MsgUpdate) (types.Asset, error) {
+	if !asset.Status || len(asset.Calendar) > 0 {
+		return types.Asset{}, ErrAssetRented(k.codespace, msg.UUID)
+	}
 
 	store := ctx.KVStore(k.storeKey)
+	deleteIndexes(store, asset)
 
-	assetBytes := store.Get([]byte(msg.UUID))
+	// Shares of the previous owner move along with the asset. Operators were
+	// chosen by the previous owner
+	asset.TransferShares(asset.Creator, msg.NewOwner, asset.GetShares(asset.Creator))
+	asset.Creator = msg.NewOwner
+	asset.Operators = nil
 
-	if assetBytes == nil {
-		return types.Asset{}, errors.New("Asset is not found")
+	k.recordChange(ctx, HISTORY_TRANSFER, signer, asset)
+	if err := k.setAsset(ctx, asset); err != nil {
+		return types.Asset{}, err
 	}
+	setIndexes(store, asset)
 
-	var asset types.Asset
+	return asset, nil
+}
+
+// GrantOperator - let msg.Operator manage an asset of signer
+func (k Keeper) GrantOperator(ctx sdk.Context, msg msg.MsgGrantOperator, signer sdk.Address) (types.Asset, sdk.Error) {
+
+	asset, err := k.getAsset(ctx, msg.UUID)
+	if err != nil {
+		return types.Asset{}, err
+	}
+
+	if !bytes.Equal(asset.Creator, signer) {
+		return types.Asset{}, ErrNotAssetOwner(k.codespace, signer, msg.UUID)
+	}
 
-	derr := json.Unmarshal(assetBytes, &asset)
-	if derr != nil {
-		return types.Asset{}, errors.New("Asset decoding error")
+	if asset.CanManage(msg.Operator) {
+		return types.Asset{}, ErrOperatorExists(k.codespace, msg.Operator, msg.UUID)
 	}
 
+	if bytes.Equal(asset.Arbiter, msg.Operator) {
+		return types.Asset{}, ErrInvalidArbiter(k.codespace, msg.Operator, msg.UUID)
+	}
+
+	asset.Operators = append(asset.Operators, msg.Operator)
+
+	k.recordChange(ctx, HISTORY_GRANT_OPERATOR, signer, asset)
+	if err := k.setAsset(ctx, asset); err != nil {
+		return types.Asset{}, err
+	}
+
+	return asset, nil
+}
+
+// RevokeOperator - remove the rights of msg.Operator. Allowed to the owner and
+// to the operator itself.
+func (k Keeper) RevokeOperator(ctx sdk.Context, msg msg.MsgRevokeOperator, signer sdk.Address) (types.Asset, sdk.Error) {
+
+	asset, err := k.getAsset(ctx, msg.UUID)
+	if err != nil {
+		return types.Asset{}, err
+	}
+
+	if !bytes.Equal(asset.Creator, signer) && !bytes.Equal(msg.Operator, signer) {
+		return types.Asset{}, ErrNotAssetOwner(k.codespace, signer, msg.UUID)
+	}
 
-	asset = types.NewAsset(msg.UUID, msg.Creator, msg.Hash, msg.Status, msg.Fee)
+	if !asset.RemoveOperator(msg.Operator) {
+		return types.Asset{}, ErrNoOperator(k.codespace, msg.Operator, msg.UUID)
+	}
 
-	nassetBytes, err := json.Marshal(asset)
+	k.recordChange(ctx, HISTORY_REVOKE_OPERATOR, signer, asset)
+	if err := k.setAsset(ctx, asset); err != nil {
+		return types.Asset{}, err
+	}
 
+	return asset, nil
+}
+
+// TransferShares - move msg.Amount shares of an asset from signer to msg.To.
+// Management of the asset stays with its owner.
+func (k Keeper) TransferShares(ctx sdk.Context, msg msg.MsgTransferShares, signer sdk.Address) (types.Asset, sdk.Error) {
+
+	asset, err := k.getAsset(ctx, msg.UUID)
 	if err != nil {
-		return types.NewAsset("", []byte(""), []byte(""), true, 0), errors.New("Asset Encoding Error")
+		return types.Asset{}, err
+	}
 
+	if !asset.TransferShares(signer, msg.To, msg.Amount) {
+		return types.Asset{}, ErrInsufficientShares(k.codespace, signer, asset.GetShares(signer), msg.UUID, msg.Amount)
 	}
 
-	store.Set([]byte(msg.UUID), nassetBytes)
+	k.recordChange(ctx, HISTORY_TRANSFER_SHARES, signer, asset)
+	if err := k.setAsset(ctx, asset); err != nil {
+		return types.Asset{}, err
+	}
 
 	return asset, nil
 }
 
-func (k Keeper) DeleteAsset(ctx sdk.Context, msg msg.MsgDelete) (types.Asset, error) {
+// GetAssetsByOwner - one page of the assets owned by owner
+func (k Keeper) GetAssetsByOwner(ctx sdk.Context, owner sdk.Address, page int, limit int) []types.Asset {
+	return k.getIndexedAssets(ctx, GetOwnerIndexPrefix(owner), page, limit)
+}
+
+// GetAssetsByCategory - one page of the assets of category
+func (k Keeper) GetAssetsByCategory(ctx sdk.Context, category string, page int, limit int) []types.Asset {
+	return k.getIndexedAssets(ctx, GetCategoryIndexPrefix(category), page, limit)
+}
+
+// GetAssetsByLocation - one page of the assets located inside the geohash cell
+func (k Keeper) GetAssetsByLocation(ctx sdk.Context, geohash string, page int, limit int) []types.Asset {
+	return k.getIndexedAssets(ctx, GetLocationIndexPrefix(geohash), page, limit)
+}
+
+// getIndexedAssets - resolve the UUIDs stored under an index prefix
+func (k Keeper) getIndexedAssets(ctx sdk.Context, prefix []byte, page int, limit int) []types.Asset {
+	assets := []types.Asset{}
+
+	for _, uuid := range utils.GetPageValues(ctx.KVStore(k.storeKey), prefix, page, limit) {
+		if asset, err := k.getAsset(ctx, string(uuid)); err == nil {
+			assets = append(assets, asset)
+		}
+	}
+
+	return assets
+}
+
+//----------------------------------------------------------
+
+func (k Keeper) getAsset(ctx sdk.Context, uuid string) (types.Asset, sdk.Error) {
 
 	store := ctx.KVStore(k.storeKey)
 
-	assetBytes := store.Get([]byte(msg.UUID))
+	assetBytes := store.Get([]byte(uuid))
 
 	if assetBytes == nil {
-		return types.NewAsset("", []byte(""), []byte(""), true, 0), errors.New("Asset is not found")
+		return types.Asset{}, ErrAssetNotFound(k.codespace, uuid)
 	}
 
-	// Unmarshall asset
 	var asset types.Asset
 
-	derr := json.Unmarshal(assetBytes, &asset)
+	if err := json.Unmarshal(assetBytes, &asset); err != nil {
+		return types.Asset{}, ErrAssetDecoding(k.codespace)
+	}
+
+	return asset, nil
+}
+
+func (k Keeper) setAsset(ctx sdk.Context, asset types.Asset) sdk.Error {
 
-	if derr != nil {
-		return types.NewAsset("", []byte(""), []byte(""), true, 0), errors.New("Asset decoding error")
+	store := ctx.KVStore(k.storeKey)
+
+	assetBytes, err := json.Marshal(asset)
+	if err != nil {
+		return ErrAssetEncoding(k.codespace)
 	}
 
-	// Delete asset
-	store.Delete([]byte(msg.UUID))
+	store.Set([]byte(asset.UUID), assetBytes)
 
-	return asset, nil
+	return nil
+}
+
+// setIndexes - add asset to the owner, category and location indexes
+func setIndexes(store sdk.KVStore, asset types.Asset) {
+	store.Set(GetOwnerIndexKey(asset.Creator, asset.UUID), []byte(asset.UUID))
+
+	if len(asset.Metadata.Category) > 0 {
+		store.Set(GetCategoryIndexKey(asset.Metadata.Category, asset.UUID), []byte(asset.UUID))
+	}
+	if len(asset.Metadata.Geohash) > 0 {
+		store.Set(GetLocationIndexKey(asset.Metadata.Geohash, asset.UUID), []byte(asset.UUID))
+	}
+}
+
+// deleteIndexes - remove asset from every index setIndexes added it to
+func deleteIndexes(store sdk.KVStore, asset types.Asset) {
+	store.Delete(GetOwnerIndexKey(asset.Creator, asset.UUID))
+	store.Delete(GetCategoryIndexKey(asset.Metadata.Category, asset.UUID))
+	store.Delete(GetLocationIndexKey(asset.Metadata.Geohash, asset.UUID))
 }
@@ -0,0 +1,84 @@
+package asset
+
+import (
+	"encoding/binary"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+)
+
+// Assets are stored under their raw UUID. Indexes use a non printable
+// prefix so they never collide with a UUID.
+var (
+	OwnerIndexKey    = []byte{0x01} // prefix for assets of an owner
+	CategoryIndexKey = []byte{0x02} // prefix for assets of a category
+	LocationIndexKey = []byte{0x03} // prefix for assets ordered by geohash
+	HistoryKey       = []byte{0x04} // prefix for the changes of an asset, oldest first
+	HistorySeqKey    = []byte{0x05} // number of asset changes recorded so far
+	HistoryQueueKey  = []byte{0x06} // prefix for asset changes waiting to be pruned, ordered by height
+)
+
+// gets the prefix of all assets of owner
+func GetOwnerIndexPrefix(owner sdk.Address) []byte {
+	return append(append([]byte{}, OwnerIndexKey...), owner...)
+}
+
+// gets the key of an asset in the index of its owner
+// VALUE: asset UUID
+func GetOwnerIndexKey(owner sdk.Address, uuid string) []byte {
+	return append(GetOwnerIndexPrefix(owner), []byte(uuid)...)
+}
+
+// gets the prefix of all assets of category. Categories never contain 0x00
+// so the separator keeps one category from matching another.
+func GetCategoryIndexPrefix(category string) []byte {
+	return append(append(append([]byte{}, CategoryIndexKey...), []byte(category)...), 0x00)
+}
+
+// gets the key of an asset in the index of its category
+// VALUE: asset UUID
+func GetCategoryIndexKey(category string, uuid string) []byte {
+	return append(GetCategoryIndexPrefix(category), []byte(uuid)...)
+}
+
+// gets the prefix of all assets located inside the geohash cell. Any
+// geohash prefix is a valid cell, shorter cells cover larger areas.
+func GetLocationIndexPrefix(geohash string) []byte {
+	return append(append([]byte{}, LocationIndexKey...), []byte(geohash)...)
+}
+
+// gets the key of an asset in the location index
+// VALUE: asset UUID
+func GetLocationIndexKey(geohash string, uuid string) []byte {
+	return append(append(GetLocationIndexPrefix(geohash), 0x00), []byte(uuid)...)
+}
+
+// gets the prefix of all changes of an asset. The UUID is length prefixed
+// so that one UUID is never a prefix of another.
+func GetHistoryPrefix(uuid string) []byte {
+	bz := make([]byte, 2)
+	binary.BigEndian.PutUint16(bz, uint16(len(uuid)))
+	return append(append(append([]byte{}, HistoryKey...), bz...), []byte(uuid)...)
+}
+
+// gets the key of a change of an asset. Sequences only grow so changes are
+// iterated in the order they were made.
+// VALUE: AssetChange
+func GetHistoryKey(uuid string, sequence int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(sequence))
+	return append(GetHistoryPrefix(uuid), bz...)
+}
+
+// gets the key of a change to prune once height is out of the retention window
+// VALUE: history key of the change
+func GetHistoryQueueKey(height int64, sequence int64) []byte {
+	bz := make([]byte, 16)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	binary.BigEndian.PutUint64(bz[8:], uint64(sequence))
+	return append(append([]byte{}, HistoryQueueKey...), bz...)
+}
+
+// gets the height encoded in a history queue key
+func getHistoryQueueHeight(key []byte) int64 {
+	return int64(binary.BigEndian.Uint64(key[len(HistoryQueueKey) : len(HistoryQueueKey)+8]))
+}
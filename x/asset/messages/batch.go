@@ -0,0 +1,92 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+)
+
+// MsgBatchAssets creates and updates many assets in one transaction. Either
+// every item is applied or none is. The signer must be the creator of every
+// created asset and the owner or an operator of every updated one.
+type MsgBatchAssets struct {
+	Creates []MsgCreate `json:"creates"`
+	Updates []MsgUpdate `json:"updates"`
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgBatchAssets{}
+
+func NewMsgBatchAssets(creates []MsgCreate, updates []MsgUpdate) MsgBatchAssets {
+	return MsgBatchAssets{
+		Creates: creates,
+		Updates: updates,
+	}
+}
+
+// Type Implements Msg
+func (msg MsgBatchAssets) Type() string {
+	return constants.MESSAGE_ASSET
+}
+
+// ValidateBasic Implements Msg. Items are validated by the keeper so that
+// every failing item is reported at once.
+func (msg MsgBatchAssets) ValidateBasic() sdk.Error {
+	if count := msg.Count(); count == 0 || count > constants.ASSET_MAX_BATCH_SIZE {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_BATCH_SIZE, constants.ASSET_MAX_BATCH_SIZE, count))
+	}
+
+	return nil
+}
+
+// Count - number of items in the batch
+func (msg MsgBatchAssets) Count() int {
+	return len(msg.Creates) + len(msg.Updates)
+}
+
+func (msg MsgBatchAssets) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (msg MsgBatchAssets) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgBatchAssets) String() string {
+	return fmt.Sprintf("Asset/MsgBatchAssets{%d creates, %d updates}", len(msg.Creates), len(msg.Updates))
+}
+
+// GetSigners - creators of the items, each required once. Items are signed
+// by their creator like the equivalent single messages.
+func (msg MsgBatchAssets) GetSigners() []sdk.Address {
+	var signers []sdk.Address
+	add := func(addr sdk.Address) {
+		for _, s := range signers {
+			if bytes.Equal(s, addr) {
+				return
+			}
+		}
+		signers = append(signers, addr)
+	}
+
+	for _, c := range msg.Creates {
+		add(c.Creator)
+	}
+	for _, u := range msg.Updates {
+		add(u.Creator)
+	}
+	return signers
+}
+
+func (msg MsgBatchAssets) Tags() sdk.Tags {
+	return sdk.NewTags("msg.module", []byte("asset")).
+		AppendTag("msg.action", []byte("batch")).
+		AppendTag("asset.created", []byte(strconv.Itoa(len(msg.Creates)))).
+		AppendTag("asset.updated", []byte(strconv.Itoa(len(msg.Updates))))
+}
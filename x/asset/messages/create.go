@@ -1,6 +1,7 @@
 package messages
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -16,6 +17,15 @@ type MsgCreate struct {
 	UUID    string      `json:"uuid"`
 	Status  bool        `json:"status"`
 	Fee     int64       `json:"fee"`
+
+	Deposit          int64               `json:"deposit"`
+	Refund           types.RefundPolicy  `json:"refund_policy"`
+	Arbiter          sdk.Address         `json:"arbiter,omitempty"`
+	ApprovalRequired bool                `json:"approval_required"`
+	LateFee          int64               `json:"late_fee"`
+	EarlyReturn      int64               `json:"early_return"`
+	Metadata         types.AssetMetadata `json:"metadata"`
+	Pricing          *types.PricingModel `json:"pricing,omitempty"`
 }
 
 // enforce the msg type at compile time
@@ -42,7 +52,36 @@ func (msg MsgCreate) ValidateBasic() sdk.Error {
 		return sdk.ErrInvalidAddress("Invalid address")
 	}
 
-	return nil
+	if err := validateUUID(msg.UUID); err != nil {
+		return err
+	}
+
+	// The owner cannot rule on disputes about its own asset
+	if len(msg.Arbiter) > 0 && bytes.Equal(msg.Arbiter, msg.Creator) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_ARBITER, msg.Arbiter, msg.UUID))
+	}
+
+	if msg.Deposit < 0 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_DEPOSIT, msg.Deposit))
+	}
+
+	if msg.LateFee < 0 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_LATE_FEE, msg.LateFee))
+	}
+
+	if msg.EarlyReturn < 0 || msg.EarlyReturn > 100 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_EARLY_RETURN, msg.EarlyReturn))
+	}
+
+	if !msg.Refund.IsValid() {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_REFUND_POLICY, msg.Refund.Kind, msg.Refund.Percent))
+	}
+
+	if msg.Pricing != nil && !msg.Pricing.IsValid() {
+		return sdk.ErrUnknownRequest(constants.ASSET_INVALID_PRICING)
+	}
+
+	return validateMetadata(msg.Metadata)
 }
 
 func (msg MsgCreate) GetSignBytes() []byte {
@@ -70,7 +109,8 @@ func (msg MsgCreate) Tags() sdk.Tags {
 		AppendTag("asset.UUID", []byte(msg.UUID)).
 		AppendTag("asset.Hash", []byte(msg.Hash)).
 		AppendTag("asset.Status", []byte(strconv.FormatBool(msg.Status))).
-		AppendTag("asset.Fee", []byte(strconv.Itoa(int(msg.Fee))))
+		AppendTag("asset.Fee", []byte(strconv.Itoa(int(msg.Fee)))).
+		AppendTag("asset.Category", []byte(msg.Metadata.Category))
 }
 
 //------------------------------------------
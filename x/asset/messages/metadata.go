@@ -0,0 +1,71 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+const (
+	categoryAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789-"
+	geohashAlphabet  = "0123456789bcdefghjkmnpqrstuvwxyz"
+	uuidAlphabet     = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_"
+)
+
+// validateUUID - asset records are stored under their UUID next to the index
+// prefixes, which are control bytes a UUID cannot start with
+func validateUUID(uuid string) sdk.Error {
+	if len(uuid) == 0 || len(uuid) > constants.ASSET_MAX_UUID_LENGTH || !onlyChars(uuid, uuidAlphabet) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_UUID,
+			constants.ASSET_MAX_UUID_LENGTH, uuid))
+	}
+	return nil
+}
+
+// validateMetadata - check metadata size limits and that category and geohash
+// only use characters safe to index
+func validateMetadata(m types.AssetMetadata) sdk.Error {
+	if len(m.Category) > constants.ASSET_MAX_CATEGORY_LENGTH || !onlyChars(m.Category, categoryAlphabet) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_CATEGORY,
+			constants.ASSET_MAX_CATEGORY_LENGTH, m.Category))
+	}
+
+	if len(m.Geohash) > constants.ASSET_MAX_GEOHASH_LENGTH || !onlyChars(m.Geohash, geohashAlphabet) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_GEOHASH,
+			constants.ASSET_MAX_GEOHASH_LENGTH, m.Geohash))
+	}
+
+	if len(m.Title) > constants.ASSET_MAX_TITLE_LENGTH {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_FIELD_TOO_LONG,
+			"title", constants.ASSET_MAX_TITLE_LENGTH, len(m.Title)))
+	}
+
+	if len(m.URI) > constants.ASSET_MAX_URI_LENGTH {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_FIELD_TOO_LONG,
+			"uri", constants.ASSET_MAX_URI_LENGTH, len(m.URI)))
+	}
+
+	if len(m.ContentHash) > constants.ASSET_MAX_CONTENT_HASH_LENGTH {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_FIELD_TOO_LONG,
+			"content hash", constants.ASSET_MAX_CONTENT_HASH_LENGTH, len(m.ContentHash)))
+	}
+
+	// Off chain content cannot be verified without its hash
+	if len(m.URI) > 0 && len(m.ContentHash) == 0 {
+		return sdk.ErrUnknownRequest(constants.ASSET_MISSING_CONTENT_HASH)
+	}
+
+	return nil
+}
+
+func onlyChars(s string, alphabet string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune(alphabet, c) {
+			return false
+		}
+	}
+	return true
+}
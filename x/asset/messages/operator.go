@@ -0,0 +1,127 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+)
+
+// MsgGrantOperator lets Operator manage an asset on behalf of its owner.
+// The owner is deduced from the signature.
+type MsgGrantOperator struct {
+	UUID     string      `json:"uuid"`
+	Operator sdk.Address `json:"operator"`
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgGrantOperator{}
+
+func NewMsgGrantOperator(uuid string, operator sdk.Address) MsgGrantOperator {
+	return MsgGrantOperator{
+		UUID:     uuid,
+		Operator: operator,
+	}
+}
+
+// Type Implements Msg
+func (msg MsgGrantOperator) Type() string {
+	return constants.MESSAGE_ASSET
+}
+
+// ValidateBasic Implements Msg
+func (msg MsgGrantOperator) ValidateBasic() sdk.Error {
+	return validateOperatorMsg(msg.UUID, msg.Operator)
+}
+
+func (msg MsgGrantOperator) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (msg MsgGrantOperator) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgGrantOperator) String() string {
+	return fmt.Sprintf("Asset/MsgGrantOperator{%s -> %s}", msg.UUID, msg.Operator)
+}
+
+func (msg MsgGrantOperator) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+func (msg MsgGrantOperator) Tags() sdk.Tags {
+	return sdk.NewTags("msg.module", []byte("asset")).
+		AppendTag("msg.action", []byte("grant_operator")).
+		AppendTag("asset.UUID", []byte(msg.UUID)).
+		AppendTag("asset.operator", []byte(msg.Operator.String()))
+}
+
+//------------------------------------------
+
+// MsgRevokeOperator removes the operator rights of Operator. It is signed by
+// the owner, or by the operator giving up its own rights.
+type MsgRevokeOperator struct {
+	UUID     string      `json:"uuid"`
+	Operator sdk.Address `json:"operator"`
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgRevokeOperator{}
+
+func NewMsgRevokeOperator(uuid string, operator sdk.Address) MsgRevokeOperator {
+	return MsgRevokeOperator{
+		UUID:     uuid,
+		Operator: operator,
+	}
+}
+
+// Type Implements Msg
+func (msg MsgRevokeOperator) Type() string {
+	return constants.MESSAGE_ASSET
+}
+
+// ValidateBasic Implements Msg
+func (msg MsgRevokeOperator) ValidateBasic() sdk.Error {
+	return validateOperatorMsg(msg.UUID, msg.Operator)
+}
+
+func (msg MsgRevokeOperator) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (msg MsgRevokeOperator) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgRevokeOperator) String() string {
+	return fmt.Sprintf("Asset/MsgRevokeOperator{%s -> %s}", msg.UUID, msg.Operator)
+}
+
+func (msg MsgRevokeOperator) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+func (msg MsgRevokeOperator) Tags() sdk.Tags {
+	return sdk.NewTags("msg.module", []byte("asset")).
+		AppendTag("msg.action", []byte("revoke_operator")).
+		AppendTag("asset.UUID", []byte(msg.UUID)).
+		AppendTag("asset.operator", []byte(msg.Operator.String()))
+}
+
+func validateOperatorMsg(uuid string, operator sdk.Address) sdk.Error {
+	if len(uuid) == 0 {
+		return sdk.ErrUnknownRequest("Asset UUID is empty")
+	}
+
+	if len(operator) == 0 {
+		return sdk.ErrInvalidAddress("Operator address is empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+)
+
+// MsgTransferShares moves Amount shares of an asset to To.
+// The current holder is deduced from the signature.
+type MsgTransferShares struct {
+	UUID   string      `json:"uuid"`
+	To     sdk.Address `json:"to"`
+	Amount int64       `json:"amount"`
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgTransferShares{}
+
+func NewMsgTransferShares(uuid string, to sdk.Address, amount int64) MsgTransferShares {
+	return MsgTransferShares{
+		UUID:   uuid,
+		To:     to,
+		Amount: amount,
+	}
+}
+
+// Type Implements Msg
+func (msg MsgTransferShares) Type() string {
+	return constants.MESSAGE_ASSET
+}
+
+// ValidateBasic Implements Msg
+func (msg MsgTransferShares) ValidateBasic() sdk.Error {
+	if len(msg.UUID) == 0 {
+		return sdk.ErrUnknownRequest("Asset UUID is empty")
+	}
+
+	if len(msg.To) == 0 {
+		return sdk.ErrInvalidAddress("Receiver address is empty")
+	}
+
+	if msg.Amount <= 0 || msg.Amount > constants.ASSET_TOTAL_SHARES {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_SHARES, constants.ASSET_TOTAL_SHARES, msg.Amount))
+	}
+
+	return nil
+}
+
+func (msg MsgTransferShares) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (msg MsgTransferShares) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgTransferShares) String() string {
+	return fmt.Sprintf("Asset/MsgTransferShares{%s %d -> %s}", msg.UUID, msg.Amount, msg.To)
+}
+
+func (msg MsgTransferShares) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+func (msg MsgTransferShares) Tags() sdk.Tags {
+	return sdk.NewTags("msg.module", []byte("asset")).
+		AppendTag("msg.action", []byte("transfer_shares")).
+		AppendTag("asset.UUID", []byte(msg.UUID)).
+		AppendTag("asset.shareReceiver", []byte(msg.To.String())).
+		AppendTag("asset.shares", []byte(strconv.FormatInt(msg.Amount, 10)))
+}
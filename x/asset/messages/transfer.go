@@ -0,0 +1,69 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+)
+
+// MsgTransferAsset moves ownership of an asset to NewOwner.
+// The current owner is deduced from the signature.
+type MsgTransferAsset struct {
+	UUID     string      `json:"uuid"`
+	NewOwner sdk.Address `json:"new_owner"`
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgTransferAsset{}
+
+func NewMsgTransferAsset(uuid string, newOwner sdk.Address) MsgTransferAsset {
+	return MsgTransferAsset{
+		UUID:     uuid,
+		NewOwner: newOwner,
+	}
+}
+
+// Type Implements Msg
+func (msg MsgTransferAsset) Type() string {
+	return constants.MESSAGE_ASSET
+}
+
+// ValidateBasic Implements Msg
+func (msg MsgTransferAsset) ValidateBasic() sdk.Error {
+	if len(msg.UUID) == 0 {
+		return sdk.ErrUnknownRequest("Asset UUID is empty")
+	}
+
+	if len(msg.NewOwner) == 0 {
+		return sdk.ErrInvalidAddress("New owner address is empty")
+	}
+
+	return nil
+}
+
+func (msg MsgTransferAsset) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (msg MsgTransferAsset) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgTransferAsset) String() string {
+	return fmt.Sprintf("Asset/MsgTransferAsset{%s -> %s}", msg.UUID, msg.NewOwner)
+}
+
+func (msg MsgTransferAsset) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+func (msg MsgTransferAsset) Tags() sdk.Tags {
+	return sdk.NewTags("msg.module", []byte("asset")).
+		AppendTag("msg.action", []byte("transfer")).
+		AppendTag("asset.UUID", []byte(msg.UUID)).
+		AppendTag("asset.newOwner", []byte(msg.NewOwner.String()))
+}
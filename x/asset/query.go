@@ -0,0 +1,261 @@
+package asset
+
+import (
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	wire "bitbucket.org/shareringvn/cosmos-sdk/wire"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+
+	abci "github.com/tendermint/abci/types"
+)
+
+// query endpoints supported by the asset Querier
+const (
+	QueryAsset    = "asset"
+	QueryOwner    = "owner"
+	QueryCategory = "category"
+	QueryLocation = "location"
+	QueryCapTable = "captable"
+	QueryHistory  = "history"
+)
+
+// creates a querier for asset REST endpoints
+func NewQuerier(k Keeper, cdc *wire.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryAsset:
+			return queryAsset(ctx, cdc, req, k)
+		case QueryOwner:
+			return queryOwner(ctx, cdc, req, k)
+		case QueryCategory:
+			return queryCategory(ctx, cdc, req, k)
+		case QueryLocation:
+			return queryLocation(ctx, cdc, req, k)
+		case QueryCapTable:
+			return queryCapTable(ctx, cdc, req, k)
+		case QueryHistory:
+			return queryHistory(ctx, cdc, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown asset query endpoint")
+		}
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/asset/asset'
+// - 'custom/asset/captable'
+type QueryAssetParams struct {
+	UUID string
+}
+
+// defines the params for the following queries:
+// - 'custom/asset/owner'
+type QueryOwnerParams struct {
+	Owner sdk.Address
+	Page  int
+	Limit int
+}
+
+// defines the params for the following queries:
+// - 'custom/asset/category'
+type QueryCategoryParams struct {
+	Category string
+	Page     int
+	Limit    int
+}
+
+// defines the params for the following queries:
+// - 'custom/asset/location'
+type QueryLocationParams struct {
+	Geohash string
+	Page    int
+	Limit   int
+}
+
+// defines the params for the following queries:
+// - 'custom/asset/history'
+type QueryHistoryParams struct {
+	UUID  string
+	Page  int
+	Limit int
+}
+
+func queryAsset(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k Keeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryAssetParams
+
+	errRes := cdc.UnmarshalBinary(req.Data, &params)
+	if errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_PARAMS, errRes.Error()))
+	}
+
+	asset, err := k.getAsset(ctx, params.UUID)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	res, errRes = cdc.MarshalJSON(asset)
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.ASSET_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
+
+func queryOwner(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k Keeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryOwnerParams
+
+	errRes := cdc.UnmarshalBinary(req.Data, &params)
+	if errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_PARAMS, errRes.Error()))
+	}
+
+	res, errRes = cdc.MarshalJSON(k.GetAssetsByOwner(ctx, params.Owner, params.Page, params.Limit))
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.ASSET_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
+
+func queryCategory(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k Keeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryCategoryParams
+
+	errRes := cdc.UnmarshalBinary(req.Data, &params)
+	if errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_PARAMS, errRes.Error()))
+	}
+
+	res, errRes = cdc.MarshalJSON(k.GetAssetsByCategory(ctx, params.Category, params.Page, params.Limit))
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.ASSET_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
+
+func queryLocation(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k Keeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryLocationParams
+
+	errRes := cdc.UnmarshalBinary(req.Data, &params)
+	if errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_PARAMS, errRes.Error()))
+	}
+
+	res, errRes = cdc.MarshalJSON(k.GetAssetsByLocation(ctx, params.Geohash, params.Page, params.Limit))
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.ASSET_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
+
+// cap table returned by 'custom/asset/captable'
+type QueryCapTableResult struct {
+	UUID        string        `json:"uuid"`
+	Owner       sdk.Address   `json:"owner"` // manages the asset and receives rounding dust
+	TotalShares int64         `json:"total_shares"`
+	Shares      []types.Share `json:"shares"`
+}
+
+func NewQueryCapTableResult(asset types.Asset) QueryCapTableResult {
+	return QueryCapTableResult{
+		UUID:        asset.UUID,
+		Owner:       asset.Creator,
+		TotalShares: constants.ASSET_TOTAL_SHARES,
+		Shares:      asset.GetCapTable(),
+	}
+}
+
+func queryCapTable(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k Keeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryAssetParams
+
+	errRes := cdc.UnmarshalBinary(req.Data, &params)
+	if errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_PARAMS, errRes.Error()))
+	}
+
+	asset, err := k.getAsset(ctx, params.UUID)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	res, errRes = cdc.MarshalJSON(NewQueryCapTableResult(asset))
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.ASSET_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
+
+func queryHistory(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k Keeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryHistoryParams
+
+	errRes := cdc.UnmarshalBinary(req.Data, &params)
+	if errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_PARAMS, errRes.Error()))
+	}
+
+	res, errRes = cdc.MarshalJSON(k.GetHistory(ctx, params.UUID, params.Page, params.Limit))
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.ASSET_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
@@ -62,6 +62,19 @@ func (am AccountMapper) SetAccount(ctx sdk.Context, acc BaseAccount) {
 	store.Set(AddressToKey(addr), bz)
 }
 
+// IterateAccounts - call process on every account until it returns true
+func (am AccountMapper) IterateAccounts(ctx sdk.Context, process func(BaseAccount) (stop bool)) {
+	store := ctx.KVStore(am.key)
+	iterator := sdk.KVStorePrefixIterator(store, []byte(constants.PREFIX_ADDRESS))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if process(am.decodeAccount(iterator.Value())) {
+			return
+		}
+	}
+}
+
 func (am AccountMapper) GetPubKey(ctx sdk.Context, addr sdk.Address) (types.PubKey, sdk.Error) {
 	acc := am.GetAccount(ctx, addr)
 	if acc == nil {
@@ -138,10 +151,9 @@ func (am AccountMapper) encodeAccount(acc BaseAccount) []byte {
 }
 
 func (am AccountMapper) decodeAccount(bz []byte) (acc BaseAccount) {
-	var acc1 *SHRAccount
-	err := am.cdc.UnmarshalBinaryBare(bz, &acc1)
+	err := am.cdc.UnmarshalBinaryBare(bz, &acc)
 	if err != nil {
 		panic(err)
 	}
-	return acc1
+	return acc
 }
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"bytes"
+	"testing"
+
+	abci "github.com/tendermint/abci/types"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	"bitbucket.org/shareringvn/cosmos-sdk/store"
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"bitbucket.org/shareringvn/cosmos-sdk/wire"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// registerKeys - public keys as registered by the app codec
+func registerKeys(cdc *wire.Codec) *wire.Codec {
+	cdc.RegisterInterface((*types.PubKey)(nil), nil)
+	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
+	return cdc
+}
+
+// legacyAccountCodec - accounts registered by value, as stored before vesting accounts
+func legacyAccountCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	cdc.RegisterInterface((*BaseAccount)(nil), nil)
+	cdc.RegisterConcrete(SHRAccount{}, "shareledger/SHRAccount", nil)
+	return registerKeys(cdc)
+}
+
+// accountCodec - accounts registered by pointer, as in app.MakeCodec
+func accountCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	cdc.RegisterInterface((*BaseAccount)(nil), nil)
+	cdc.RegisterConcrete(&SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterConcrete(&ContinuousVestingAccount{}, "shareledger/ContinuousVestingAccount", nil)
+	cdc.RegisterConcrete(&PeriodicVestingAccount{}, "shareledger/PeriodicVestingAccount", nil)
+	return registerKeys(cdc)
+}
+
+func setupAccountMapper(t *testing.T) (sdk.Context, AccountMapper, sdk.KVStore) {
+	db := dbm.NewMemDB()
+	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(authKey, sdk.StoreTypeIAVL, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	return ctx, NewAccountMapper(accountCodec(), authKey, &SHRAccount{}), ctx.KVStore(authKey)
+}
+
+func TestDecodeLegacyAccount(t *testing.T) {
+	ctx, am, accStore := setupAccountMapper(t)
+
+	pubKey, _ := types.GenerateKeyPair()
+	legacy := NewSHRAccountWithAddress(pubKey.Address())
+	legacy.SetPubKey(pubKey)
+	legacy.SetNonce(7)
+	legacy.SetCoins(vestingCoins(1000))
+
+	// Written the way the account store encoded accounts before
+	bz, err := legacyAccountCodec().MarshalBinaryBare(legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accStore.Set(AddressToKey(legacy.Address), bz)
+
+	acc := am.GetAccount(ctx, legacy.Address)
+	shrAcc, ok := acc.(*SHRAccount)
+	if !ok {
+		t.Fatalf("Legacy account should decode as *SHRAccount but is %T.", acc)
+	}
+	if !bytes.Equal(shrAcc.Address, legacy.Address) || shrAcc.Nonce != 7 ||
+		!shrAcc.PubKey.Equals(pubKey) || !shrAcc.Coins.AmountOf("SHR").Equal(types.NewDec(1000)) {
+		t.Errorf("Legacy account decoded as %s instead of %s.", shrAcc, legacy)
+	}
+
+	// Storing it again keeps the same encoding
+	am.SetAccount(ctx, acc)
+	if !bytes.Equal(accStore.Get(AddressToKey(legacy.Address)), bz) {
+		t.Errorf("Account encoding changed from %X to %X.", bz, accStore.Get(AddressToKey(legacy.Address)))
+	}
+}
+
+func TestAccountMapperRoundTrip(t *testing.T) {
+	ctx, am, _ := setupAccountMapper(t)
+
+	acc := NewSHRAccountWithAddress(sdk.Address([]byte("investor")))
+	acc.SetCoins(vestingCoins(1000))
+	vacc := NewContinuousVestingAccount(*acc, vestingCoins(1000), 100, 200)
+	am.SetAccount(ctx, vacc)
+
+	decoded, ok := am.GetAccount(ctx, acc.Address).(*ContinuousVestingAccount)
+	if !ok {
+		t.Fatalf("Vesting account should decode as *ContinuousVestingAccount but is %T.", am.GetAccount(ctx, acc.Address))
+	}
+	if decoded.GetEndTime() != 200 || !decoded.GetOriginalVesting().AmountOf("SHR").Equal(types.NewDec(1000)) {
+		t.Errorf("Vesting account decoded as %v instead of %v.", decoded, vacc)
+	}
+}
@@ -0,0 +1,306 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// VestingAccount is an account whose OriginalVesting coins unlock over time.
+// Locked coins cannot be spent but may be delegated. Times are unix times of
+// the block header.
+type VestingAccount interface {
+	BaseAccount
+
+	GetVestedCoins(blockTime int64) types.Coins
+	GetVestingCoins(blockTime int64) types.Coins
+	GetLockedCoins(blockTime int64) types.Coins
+
+	TrackDelegation(blockTime int64, amt types.Coins)
+	TrackUndelegation(amt types.Coins)
+
+	GetOriginalVesting() types.Coins
+	GetDelegatedFree() types.Coins
+	GetDelegatedVesting() types.Coins
+	GetEndTime() int64
+}
+
+// SpendableCoins - coins of acc that can be sent or burnt at blockTime. Every
+// coin of an account which does not vest is spendable.
+func SpendableCoins(acc BaseAccount, blockTime int64) types.Coins {
+	vacc, ok := acc.(VestingAccount)
+	if !ok {
+		return acc.GetCoins()
+	}
+
+	spendable := types.NewDefaultCoins()
+	locked := vacc.GetLockedCoins(blockTime)
+	for _, denom := range constants.ALL_DENOMS {
+		amt := types.MaxDec(acc.GetCoins().AmountOf(denom).Sub(locked.AmountOf(denom)), types.ZeroDec())
+		spendable = spendable.Plus(types.NewCoinFromDec(denom, amt))
+	}
+	return spendable
+}
+
+//-------------------------------------------------------
+// BaseVestingAccount
+
+// BaseVestingAccount - fields shared by the vesting accounts. DelegatedFree
+// and DelegatedVesting split delegated coins between unlocked and locked ones.
+type BaseVestingAccount struct {
+	SHRAccount
+
+	OriginalVesting  types.Coins `json:"original_vesting"`
+	DelegatedFree    types.Coins `json:"delegated_free"`
+	DelegatedVesting types.Coins `json:"delegated_vesting"`
+	EndTime          int64       `json:"end_time"` // every coin is vested at EndTime
+}
+
+func newBaseVestingAccount(acc SHRAccount, originalVesting types.Coins, endTime int64) BaseVestingAccount {
+	return BaseVestingAccount{
+		SHRAccount:       acc,
+		OriginalVesting:  originalVesting,
+		DelegatedFree:    types.NewDefaultCoins(),
+		DelegatedVesting: types.NewDefaultCoins(),
+		EndTime:          endTime,
+	}
+}
+
+func (bva BaseVestingAccount) GetOriginalVesting() types.Coins  { return bva.OriginalVesting }
+func (bva BaseVestingAccount) GetDelegatedFree() types.Coins    { return bva.DelegatedFree }
+func (bva BaseVestingAccount) GetDelegatedVesting() types.Coins { return bva.DelegatedVesting }
+func (bva BaseVestingAccount) GetEndTime() int64                { return bva.EndTime }
+
+// lockedCoins - vesting coins not delegated yet
+func (bva BaseVestingAccount) lockedCoins(vesting types.Coins) types.Coins {
+	locked := types.NewDefaultCoins()
+	for _, denom := range constants.ALL_DENOMS {
+		amt := vesting.AmountOf(denom).Sub(bva.DelegatedVesting.AmountOf(denom))
+		locked = locked.Plus(types.NewCoinFromDec(denom, types.MaxDec(amt, types.ZeroDec())))
+	}
+	return locked
+}
+
+// trackDelegation - delegated coins are taken from the vesting coins first
+func (bva *BaseVestingAccount) trackDelegation(vesting types.Coins, amt types.Coins) {
+	free := types.NewDefaultCoins().PlusMany(bva.DelegatedFree)
+	delegated := types.NewDefaultCoins().PlusMany(bva.DelegatedVesting)
+	for _, denom := range constants.ALL_DENOMS {
+		total := amt.AmountOf(denom)
+		if total.IsZero() {
+			continue
+		}
+
+		// undelegated vesting coins
+		available := types.MaxDec(vesting.AmountOf(denom).Sub(delegated.AmountOf(denom)), types.ZeroDec())
+		fromVesting := types.MinDec(available, total)
+
+		delegated = delegated.PlusMany(types.Coins{types.NewCoinFromDec(denom, fromVesting)})
+		free = free.PlusMany(types.Coins{types.NewCoinFromDec(denom, total.Sub(fromVesting))})
+	}
+	bva.DelegatedFree, bva.DelegatedVesting = free, delegated
+}
+
+// TrackUndelegation - undelegated coins are given back to the free coins first
+func (bva *BaseVestingAccount) TrackUndelegation(amt types.Coins) {
+	free := types.NewDefaultCoins().PlusMany(bva.DelegatedFree)
+	delegated := types.NewDefaultCoins().PlusMany(bva.DelegatedVesting)
+	for _, denom := range constants.ALL_DENOMS {
+		total := amt.AmountOf(denom)
+		if total.IsZero() {
+			continue
+		}
+
+		toFree := types.MinDec(free.AmountOf(denom), total)
+		toVesting := types.MinDec(delegated.AmountOf(denom), total.Sub(toFree))
+
+		free = free.MinusMany(types.Coins{types.NewCoinFromDec(denom, toFree)})
+		delegated = delegated.MinusMany(types.Coins{types.NewCoinFromDec(denom, toVesting)})
+	}
+	bva.DelegatedFree, bva.DelegatedVesting = free, delegated
+}
+
+//-------------------------------------------------------
+// ContinuousVestingAccount
+
+var _ VestingAccount = (*ContinuousVestingAccount)(nil)
+
+// ContinuousVestingAccount - OriginalVesting unlocks linearly between
+// StartTime and EndTime
+type ContinuousVestingAccount struct {
+	BaseVestingAccount
+
+	StartTime int64 `json:"start_time"`
+}
+
+func NewContinuousVestingAccount(
+	acc SHRAccount,
+	originalVesting types.Coins,
+	startTime int64,
+	endTime int64,
+) *ContinuousVestingAccount {
+	return &ContinuousVestingAccount{
+		BaseVestingAccount: newBaseVestingAccount(acc, originalVesting, endTime),
+		StartTime:          startTime,
+	}
+}
+
+func (cva ContinuousVestingAccount) String() string {
+	if v, err := json.Marshal(cva); err != nil {
+		panic(err)
+	} else {
+		return fmt.Sprintf("%s", v)
+	}
+}
+
+// GetVestedCoins - part of OriginalVesting unlocked at blockTime, in whole coins
+func (cva ContinuousVestingAccount) GetVestedCoins(blockTime int64) types.Coins {
+	if blockTime <= cva.StartTime {
+		return types.NewDefaultCoins()
+	}
+	if blockTime >= cva.EndTime {
+		return types.NewDefaultCoins().PlusMany(cva.OriginalVesting)
+	}
+
+	elapsed := types.NewDec(blockTime - cva.StartTime)
+	duration := types.NewDec(cva.EndTime - cva.StartTime)
+
+	vested := types.NewDefaultCoins()
+	for _, c := range cva.OriginalVesting {
+		amt := c.Amount.Mul(elapsed).Quo(duration).TruncateInt64()
+		vested = vested.Plus(types.NewCoin(c.Denom, amt))
+	}
+	return vested
+}
+
+// GetVestingCoins - part of OriginalVesting still locked at blockTime
+func (cva ContinuousVestingAccount) GetVestingCoins(blockTime int64) types.Coins {
+	return types.NewDefaultCoins().PlusMany(cva.OriginalVesting).MinusMany(cva.GetVestedCoins(blockTime))
+}
+
+// GetLockedCoins - coins that cannot be spent at blockTime
+func (cva ContinuousVestingAccount) GetLockedCoins(blockTime int64) types.Coins {
+	return cva.lockedCoins(cva.GetVestingCoins(blockTime))
+}
+
+// TrackDelegation - record a delegation of amt at blockTime
+func (cva *ContinuousVestingAccount) TrackDelegation(blockTime int64, amt types.Coins) {
+	cva.trackDelegation(cva.GetVestingCoins(blockTime), amt)
+}
+
+//-------------------------------------------------------
+// PeriodicVestingAccount
+
+var _ VestingAccount = (*PeriodicVestingAccount)(nil)
+
+// VestingPeriod - Amount unlocked Length seconds after the previous period
+type VestingPeriod struct {
+	Length int64       `json:"length"`
+	Amount types.Coins `json:"amount"`
+}
+
+// PeriodicVestingAccount - OriginalVesting unlocks in steps, each period
+// unlocking its Amount once it ends. EndTime is the end of the last period.
+type PeriodicVestingAccount struct {
+	BaseVestingAccount
+
+	StartTime int64           `json:"start_time"`
+	Periods   []VestingPeriod `json:"periods"`
+}
+
+// NewPeriodicVestingAccount - OriginalVesting is the sum of every period
+func NewPeriodicVestingAccount(acc SHRAccount, startTime int64, periods []VestingPeriod) *PeriodicVestingAccount {
+	originalVesting := types.NewDefaultCoins()
+	endTime := startTime
+	for _, p := range periods {
+		originalVesting = originalVesting.PlusMany(p.Amount)
+		endTime += p.Length
+	}
+
+	return &PeriodicVestingAccount{
+		BaseVestingAccount: newBaseVestingAccount(acc, originalVesting, endTime),
+		StartTime:          startTime,
+		Periods:            periods,
+	}
+}
+
+func (pva PeriodicVestingAccount) String() string {
+	if v, err := json.Marshal(pva); err != nil {
+		panic(err)
+	} else {
+		return fmt.Sprintf("%s", v)
+	}
+}
+
+// GetVestedCoins - Amount of every period ended at blockTime
+func (pva PeriodicVestingAccount) GetVestedCoins(blockTime int64) types.Coins {
+	vested := types.NewDefaultCoins()
+
+	end := pva.StartTime
+	for _, p := range pva.Periods {
+		end += p.Length
+		if blockTime < end {
+			break
+		}
+		vested = vested.PlusMany(p.Amount)
+	}
+	return vested
+}
+
+// GetVestingCoins - part of OriginalVesting still locked at blockTime
+func (pva PeriodicVestingAccount) GetVestingCoins(blockTime int64) types.Coins {
+	return types.NewDefaultCoins().PlusMany(pva.OriginalVesting).MinusMany(pva.GetVestedCoins(blockTime))
+}
+
+// GetLockedCoins - coins that cannot be spent at blockTime
+func (pva PeriodicVestingAccount) GetLockedCoins(blockTime int64) types.Coins {
+	return pva.lockedCoins(pva.GetVestingCoins(blockTime))
+}
+
+// TrackDelegation - record a delegation of amt at blockTime
+func (pva *PeriodicVestingAccount) TrackDelegation(blockTime int64, amt types.Coins) {
+	pva.trackDelegation(pva.GetVestingCoins(blockTime), amt)
+}
+
+//-------------------------------------------------------
+
+// ValidateVestingSchedule - positive coins of allowed denoms vesting over a
+// non empty time range
+func ValidateVestingSchedule(originalVesting types.Coins, startTime int64, endTime int64) error {
+	if endTime <= startTime {
+		return fmt.Errorf(constants.VESTING_INVALID_TIME, startTime, endTime)
+	}
+
+	if len(originalVesting) == 0 {
+		return fmt.Errorf(constants.VESTING_INVALID_AMOUNT, originalVesting)
+	}
+
+	for _, c := range originalVesting {
+		if !c.HasValidDenom() || !c.IsPositive() {
+			return fmt.Errorf(constants.VESTING_INVALID_AMOUNT, originalVesting)
+		}
+	}
+	return nil
+}
+
+// ValidateVestingPeriods - every period has a positive length and amount
+func ValidateVestingPeriods(startTime int64, periods []VestingPeriod) error {
+	if len(periods) == 0 {
+		return fmt.Errorf(constants.VESTING_INVALID_PERIODS)
+	}
+
+	end := startTime
+	for _, p := range periods {
+		if p.Length <= 0 {
+			return fmt.Errorf(constants.VESTING_INVALID_PERIODS)
+		}
+		end += p.Length
+
+		if err := ValidateVestingSchedule(p.Amount, startTime, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
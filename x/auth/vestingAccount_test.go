@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"testing"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/types"
+)
+
+func vestingCoins(shr int64) types.Coins {
+	coins := types.NewDefaultCoins()
+	return coins.Plus(types.NewCoin("SHR", shr))
+}
+
+func TestContinuousVestingAccount(t *testing.T) {
+	acc := NewSHRAccountWithAddress(sdk.Address([]byte("investor")))
+	acc.SetCoins(vestingCoins(1000))
+
+	vacc := NewContinuousVestingAccount(*acc, vestingCoins(1000), 100, 200)
+
+	table := []struct {
+		time      int64
+		spendable int64
+	}{
+		{50, 0},
+		{100, 0},
+		{150, 500},
+		{175, 750},
+		{200, 1000},
+		{300, 1000},
+	}
+
+	for _, tc := range table {
+		spendable := SpendableCoins(vacc, tc.time).AmountOf("SHR")
+		if !spendable.Equal(types.NewDec(tc.spendable)) {
+			t.Errorf("Spendable at %d should be %d but is %s.", tc.time, tc.spendable, spendable)
+		}
+	}
+}
+
+func TestPeriodicVestingAccount(t *testing.T) {
+	acc := NewSHRAccountWithAddress(sdk.Address([]byte("team")))
+	acc.SetCoins(vestingCoins(300))
+
+	periods := []VestingPeriod{
+		{Length: 10, Amount: types.Coins{types.NewCoin("SHR", 100)}},
+		{Length: 10, Amount: types.Coins{types.NewCoin("SHR", 200)}},
+	}
+	vacc := NewPeriodicVestingAccount(*acc, 100, periods)
+
+	if vacc.GetEndTime() != 120 || !vacc.GetOriginalVesting().AmountOf("SHR").Equal(types.NewDec(300)) {
+		t.Errorf("Vesting should end at 120 with 300SHR but ends at %d with %s.", vacc.GetEndTime(), vacc.GetOriginalVesting())
+	}
+
+	table := []struct {
+		time   int64
+		vested int64
+	}{
+		{105, 0},
+		{110, 100},
+		{119, 100},
+		{120, 300},
+	}
+
+	for _, tc := range table {
+		vested := vacc.GetVestedCoins(tc.time).AmountOf("SHR")
+		if !vested.Equal(types.NewDec(tc.vested)) {
+			t.Errorf("Vested at %d should be %d but is %s.", tc.time, tc.vested, vested)
+		}
+	}
+}
+
+func TestTrackDelegation(t *testing.T) {
+	acc := NewSHRAccountWithAddress(sdk.Address([]byte("investor")))
+	acc.SetCoins(vestingCoins(1500))
+
+	// 1000SHR vesting, 500SHR free, half of the vesting coins unlocked at 150
+	vacc := NewContinuousVestingAccount(*acc, vestingCoins(1000), 100, 200)
+
+	// delegating 800SHR takes the 500SHR still vesting first
+	vacc.TrackDelegation(150, vestingCoins(800))
+	vacc.SetCoins(vestingCoins(700))
+
+	if !vacc.GetDelegatedVesting().AmountOf("SHR").Equal(types.NewDec(500)) ||
+		!vacc.GetDelegatedFree().AmountOf("SHR").Equal(types.NewDec(300)) {
+		t.Errorf("Delegated should be 500SHR vesting and 300SHR free but is %s and %s.",
+			vacc.GetDelegatedVesting(), vacc.GetDelegatedFree())
+	}
+
+	// every coin left is spendable since the locked ones are delegated
+	if spendable := SpendableCoins(vacc, 150).AmountOf("SHR"); !spendable.Equal(types.NewDec(700)) {
+		t.Errorf("Spendable should be 700SHR but is %s.", spendable)
+	}
+
+	// undelegating gives back the free coins first
+	vacc.TrackUndelegation(vestingCoins(400))
+	vacc.SetCoins(vestingCoins(1100))
+
+	if !vacc.GetDelegatedVesting().AmountOf("SHR").Equal(types.NewDec(400)) ||
+		!vacc.GetDelegatedFree().AmountOf("SHR").IsZero() {
+		t.Errorf("Delegated should be 400SHR vesting and no free coin but is %s and %s.",
+			vacc.GetDelegatedVesting(), vacc.GetDelegatedFree())
+	}
+
+	if spendable := SpendableCoins(vacc, 150).AmountOf("SHR"); !spendable.Equal(types.NewDec(1000)) {
+		t.Errorf("Spendable should be 1000SHR but is %s.", spendable)
+	}
+}
@@ -0,0 +1,184 @@
+package bank
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/bank/messages"
+)
+
+// Allowance - coins of Granter that Grantee may still send with MsgSendFrom.
+// Expired allowances stay stored until revoked but cannot be used.
+type Allowance struct {
+	Granter    sdk.Address `json:"granter"`
+	Grantee    sdk.Address `json:"grantee"`
+	Limit      types.Coins `json:"limit"`      // left to spend, per denom
+	Expiration int64       `json:"expiration"` // unix time, 0 never expires
+}
+
+// IsExpired - whether the allowance can no longer be used at blockTime
+func (a Allowance) IsExpired(blockTime int64) bool {
+	return a.Expiration != 0 && blockTime >= a.Expiration
+}
+
+// AllowanceKeeper stores the allowances granted between accounts in the bank
+// store
+type AllowanceKeeper struct {
+	storeKey sdk.StoreKey
+}
+
+func NewAllowanceKeeper(key sdk.StoreKey) AllowanceKeeper {
+	return AllowanceKeeper{storeKey: key}
+}
+
+// GetAllowance - allowance granted by granter to grantee
+func (k AllowanceKeeper) GetAllowance(ctx sdk.Context, granter sdk.Address, grantee sdk.Address) (Allowance, bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(GetAllowanceKey(granter, grantee))
+	if bz == nil {
+		return Allowance{}, false
+	}
+
+	var allowance Allowance
+	if err := json.Unmarshal(bz, &allowance); err != nil {
+		panic(err)
+	}
+	return allowance, true
+}
+
+func (k AllowanceKeeper) setAllowance(ctx sdk.Context, allowance Allowance) {
+	store := ctx.KVStore(k.storeKey)
+
+	if err := utils.Store(store, GetAllowanceKey(allowance.Granter, allowance.Grantee), allowance); err != nil {
+		panic(err)
+	}
+	store.Set(GetGranteeIndexKey(allowance.Grantee, allowance.Granter), allowance.Granter)
+}
+
+func (k AllowanceKeeper) removeAllowance(ctx sdk.Context, granter sdk.Address, grantee sdk.Address) {
+	store := ctx.KVStore(k.storeKey)
+
+	store.Delete(GetAllowanceKey(granter, grantee))
+	store.Delete(GetGranteeIndexKey(grantee, granter))
+}
+
+// GetAllowancesByGranter - every allowance granted by granter
+func (k AllowanceKeeper) GetAllowancesByGranter(ctx sdk.Context, granter sdk.Address) []Allowance {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), GetAllowancePrefix(granter))
+	defer iter.Close()
+
+	allowances := []Allowance{}
+	for ; iter.Valid(); iter.Next() {
+		var allowance Allowance
+		if err := json.Unmarshal(iter.Value(), &allowance); err != nil {
+			panic(err)
+		}
+		allowances = append(allowances, allowance)
+	}
+	return allowances
+}
+
+// GetAllowancesByGrantee - every allowance granted to grantee
+func (k AllowanceKeeper) GetAllowancesByGrantee(ctx sdk.Context, grantee sdk.Address) []Allowance {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), GetGranteeIndexPrefix(grantee))
+	defer iter.Close()
+
+	allowances := []Allowance{}
+	for ; iter.Valid(); iter.Next() {
+		if allowance, found := k.GetAllowance(ctx, iter.Value(), grantee); found {
+			allowances = append(allowances, allowance)
+		}
+	}
+	return allowances
+}
+
+// GrantAllowance - allow grantee to send up to limit of the coins of granter
+// until expiration, replacing any previous allowance between them. The limit
+// must be positive in distinct known denoms.
+func (k AllowanceKeeper) GrantAllowance(
+	ctx sdk.Context,
+	granter sdk.Address,
+	grantee sdk.Address,
+	limit types.Coins,
+	expiration int64,
+) (Allowance, sdk.Error) {
+	if bytes.Equal(granter, grantee) {
+		return Allowance{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ALLOWANCE_SELF, granter))
+	}
+
+	if err := messages.ValidateAmount(limit); err != nil {
+		return Allowance{}, err
+	}
+
+	if expiration != 0 && expiration <= ctx.BlockHeader().Time {
+		return Allowance{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ALLOWANCE_EXPIRED_GRANT, expiration))
+	}
+
+	allowance := Allowance{
+		Granter:    granter,
+		Grantee:    grantee,
+		Limit:      limit,
+		Expiration: expiration,
+	}
+	k.setAllowance(ctx, allowance)
+
+	return allowance, nil
+}
+
+// RevokeAllowance - remove the allowance granted by granter to grantee
+func (k AllowanceKeeper) RevokeAllowance(ctx sdk.Context, granter sdk.Address, grantee sdk.Address) sdk.Error {
+	if _, found := k.GetAllowance(ctx, granter, grantee); !found {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ALLOWANCE_NOT_FOUND, grantee, granter))
+	}
+
+	k.removeAllowance(ctx, granter, grantee)
+	return nil
+}
+
+// UseAllowance - count amt sent by grantee from granter against the
+// allowance. The allowance is removed once nothing is left to spend.
+func (k AllowanceKeeper) UseAllowance(
+	ctx sdk.Context,
+	granter sdk.Address,
+	grantee sdk.Address,
+	amt types.Coins,
+) sdk.Error {
+	allowance, found := k.GetAllowance(ctx, granter, grantee)
+	if !found {
+		return sdk.ErrUnauthorized(fmt.Sprintf(constants.ALLOWANCE_NOT_FOUND, grantee, granter))
+	}
+
+	if allowance.IsExpired(ctx.BlockHeader().Time) {
+		return sdk.ErrUnauthorized(fmt.Sprintf(constants.ALLOWANCE_EXPIRED, grantee, granter, allowance.Expiration))
+	}
+
+	for _, c := range amt {
+		if allowance.Limit.AmountOf(c.Denom).LT(c.Amount) {
+			return sdk.ErrUnauthorized(fmt.Sprintf(constants.ALLOWANCE_EXCEEDED, grantee, granter, allowance.Limit, amt))
+		}
+	}
+
+	left := types.Coins{}
+	for _, c := range allowance.Limit {
+		amount := c.Amount.Sub(amt.AmountOf(c.Denom))
+		if amount.IsPositive() {
+			left = append(left, types.NewCoinFromDec(c.Denom, amount))
+		}
+	}
+
+	if len(left) == 0 {
+		k.removeAllowance(ctx, granter, grantee)
+		return nil
+	}
+
+	allowance.Limit = left
+	k.setAllowance(ctx, allowance)
+	return nil
+}
@@ -0,0 +1,162 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/abci/types"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/x/bank/messages"
+)
+
+func shrp(amount int64) types.Coins {
+	return types.Coins{types.NewCoin(constants.BOOKING_DENOM, amount)}
+}
+
+func TestGrantAndRevokeAllowance(t *testing.T) {
+	in := setupBankTest(t)
+	ctx := in.signedBy(sender)
+
+	res := in.handler(ctx, messages.NewMsgGrantAllowance(alice, shrp(30), now+100))
+	require.True(t, res.IsOK(), res.Log)
+
+	allowance, found := in.ak.GetAllowance(in.ctx, sender, alice)
+	require.True(t, found)
+	require.True(t, allowance.Limit.Equal(types.NewCoin(constants.BOOKING_DENOM, 30)))
+	require.Equal(t, now+100, allowance.Expiration)
+
+	// A new grant replaces the previous one
+	res = in.handler(ctx, messages.NewMsgGrantAllowance(alice, shrp(10), 0))
+	require.True(t, res.IsOK(), res.Log)
+	allowance, _ = in.ak.GetAllowance(in.ctx, sender, alice)
+	require.True(t, allowance.Limit.Equal(types.NewCoin(constants.BOOKING_DENOM, 10)))
+	require.Equal(t, int64(0), allowance.Expiration)
+
+	res = in.handler(ctx, messages.NewMsgRevokeAllowance(alice))
+	require.True(t, res.IsOK(), res.Log)
+	_, found = in.ak.GetAllowance(in.ctx, sender, alice)
+	require.False(t, found)
+	require.Len(t, in.ak.GetAllowancesByGrantee(in.ctx, alice), 0)
+
+	res = in.handler(ctx, messages.NewMsgRevokeAllowance(alice))
+	require.False(t, res.IsOK())
+}
+
+func TestGrantAllowanceValidation(t *testing.T) {
+	in := setupBankTest(t)
+
+	_, err := in.ak.GrantAllowance(in.ctx, sender, sender, shrp(10), 0)
+	require.NotNil(t, err)
+	_, err = in.ak.GrantAllowance(in.ctx, sender, alice, shrp(10), now)
+	require.NotNil(t, err)
+
+	// The keeper checks the limit like the message does
+	invalid := []types.Coins{
+		nil,
+		shrp(0),
+		shrp(-1),
+		{types.NewCoin("XYZ", 10)},
+		{types.NewCoin(constants.BOOKING_DENOM, 1), types.NewCoin(constants.BOOKING_DENOM, 2)},
+	}
+	for _, limit := range invalid {
+		_, err = in.ak.GrantAllowance(in.ctx, sender, alice, limit, 0)
+		require.Equal(t, sdk.CodeInvalidCoins, err.Code(), "%v", limit)
+		require.NotNil(t, messages.NewMsgGrantAllowance(alice, limit, 0).ValidateBasic(), "%v", limit)
+	}
+
+	_, found := in.ak.GetAllowance(in.ctx, sender, alice)
+	require.False(t, found)
+}
+
+func TestSendFromAllowance(t *testing.T) {
+	in := setupBankTest(t)
+	_, err := in.ak.GrantAllowance(in.ctx, sender, alice, shrp(30), 0)
+	require.Nil(t, err)
+
+	// Without an allowance nothing can be sent
+	res := in.handler(in.signedBy(bob), messages.NewMsgSendFrom(sender, bob, shrp(1)))
+	require.False(t, res.IsOK())
+
+	res = in.handler(in.signedBy(alice), messages.NewMsgSendFrom(sender, bob, shrp(20)))
+	require.True(t, res.IsOK(), res.Log)
+	require.True(t, in.balance(sender, constants.BOOKING_DENOM).Equal(types.NewCoin(constants.BOOKING_DENOM, 80)))
+	require.True(t, in.balance(bob, constants.BOOKING_DENOM).Equal(types.NewCoin(constants.BOOKING_DENOM, 20)))
+
+	allowance, _ := in.ak.GetAllowance(in.ctx, sender, alice)
+	require.True(t, allowance.Limit.Equal(types.NewCoin(constants.BOOKING_DENOM, 10)))
+
+	// Beyond the cap and in another denom
+	res = in.handler(in.signedBy(alice), messages.NewMsgSendFrom(sender, bob, shrp(11)))
+	require.False(t, res.IsOK())
+	res = in.handler(in.signedBy(alice), messages.NewMsgSendFrom(sender, bob,
+		types.Coins{types.NewCoin(constants.POS_DENOM, 1)}))
+	require.False(t, res.IsOK())
+
+	// Using up the cap removes the allowance
+	res = in.handler(in.signedBy(alice), messages.NewMsgSendFrom(sender, bob, shrp(10)))
+	require.True(t, res.IsOK(), res.Log)
+	_, found := in.ak.GetAllowance(in.ctx, sender, alice)
+	require.False(t, found)
+	require.True(t, in.balance(bob, constants.BOOKING_DENOM).Equal(types.NewCoin(constants.BOOKING_DENOM, 30)))
+}
+
+func TestAllowanceExpiry(t *testing.T) {
+	in := setupBankTest(t)
+	_, err := in.ak.GrantAllowance(in.ctx, sender, alice, shrp(30), now+10)
+	require.Nil(t, err)
+
+	ctx := in.signedBy(alice)
+	msg := messages.NewMsgSendFrom(sender, bob, shrp(5))
+
+	res := in.handler(ctx.WithBlockHeader(abci.Header{Time: now + 9}), msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	res = in.handler(ctx.WithBlockHeader(abci.Header{Time: now + 10}), msg)
+	require.False(t, res.IsOK())
+	require.True(t, in.balance(bob, constants.BOOKING_DENOM).Equal(types.NewCoin(constants.BOOKING_DENOM, 5)))
+
+	// Expired allowances stay listed until revoked
+	require.Len(t, in.ak.GetAllowancesByGranter(in.ctx, sender), 1)
+}
+
+func TestAllowanceQueries(t *testing.T) {
+	in := setupBankTest(t)
+	cdc := makeTestCodec()
+
+	_, err := in.ak.GrantAllowance(in.ctx, sender, alice, shrp(10), 0)
+	require.Nil(t, err)
+	_, err = in.ak.GrantAllowance(in.ctx, sender, bob, shrp(20), 0)
+	require.Nil(t, err)
+	_, err = in.ak.GrantAllowance(in.ctx, bob, alice, shrp(30), 0)
+	require.Nil(t, err)
+
+	query := func(route string, addr sdk.Address) []Allowance {
+		params, errRes := cdc.MarshalBinary(QueryAllowancesParams{Address: addr})
+		require.Nil(t, errRes)
+		bz, qErr := NewQuerier(in.sk, in.ak, cdc)(in.ctx, []string{route}, abci.RequestQuery{Data: params})
+		require.Nil(t, qErr)
+
+		var allowances []Allowance
+		require.Nil(t, cdc.UnmarshalJSON(bz, &allowances))
+		return allowances
+	}
+
+	granted := query(QueryGranted, sender)
+	require.Len(t, granted, 2)
+	for _, a := range granted {
+		require.Equal(t, sender, a.Granter)
+	}
+
+	received := query(QueryReceived, alice)
+	require.Len(t, received, 2)
+	for _, a := range received {
+		require.Equal(t, alice, a.Grantee)
+	}
+
+	require.Len(t, query(QueryGranted, alice), 0)
+	require.Len(t, query(QueryReceived, sender), 0)
+}
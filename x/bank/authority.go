@@ -0,0 +1,306 @@
+package bank
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+)
+
+//------------------------------------------------------------------
+// Authorities
+
+// GetAuthority - supply authority at addr
+func (k SupplyKeeper) GetAuthority(ctx sdk.Context, addr sdk.Address) (types.Authority, bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(GetAuthorityKey(addr))
+	if bz == nil {
+		return types.Authority{}, false
+	}
+
+	var authority types.Authority
+	if err := json.Unmarshal(bz, &authority); err != nil {
+		panic(err)
+	}
+	return authority, true
+}
+
+func (k SupplyKeeper) setAuthority(ctx sdk.Context, authority types.Authority) {
+	if err := utils.Store(ctx.KVStore(k.storeKey), GetAuthorityKey(authority.Address), authority); err != nil {
+		panic(err)
+	}
+}
+
+func (k SupplyKeeper) removeAuthority(ctx sdk.Context, addr sdk.Address) {
+	ctx.KVStore(k.storeKey).Delete(GetAuthorityKey(addr))
+}
+
+// GetAuthorities - every supply authority
+func (k SupplyKeeper) GetAuthorities(ctx sdk.Context) []types.Authority {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), AuthorityKey)
+	defer iter.Close()
+
+	authorities := []types.Authority{}
+	for ; iter.Valid(); iter.Next() {
+		var authority types.Authority
+		if err := json.Unmarshal(iter.Value(), &authority); err != nil {
+			panic(err)
+		}
+		authorities = append(authorities, authority)
+	}
+	return authorities
+}
+
+// HasRole - whether addr is an authority granted role
+func (k SupplyKeeper) HasRole(ctx sdk.Context, addr sdk.Address, role string) bool {
+	authority, found := k.GetAuthority(ctx, addr)
+	return found && authority.HasRole(role)
+}
+
+// IsReserve - whether addr may act as the counterparty of exchanges
+func (k SupplyKeeper) IsReserve(ctx sdk.Context, addr sdk.Address) bool {
+	return k.HasRole(ctx, addr, types.AUTHORITY_RESERVE)
+}
+
+// IsArbiter - whether addr may resolve disputes of any asset
+func (k SupplyKeeper) IsArbiter(ctx sdk.Context, addr sdk.Address) bool {
+	return k.HasRole(ctx, addr, types.AUTHORITY_ARBITER)
+}
+
+// AuthorizedMint - mint amt on behalf of minter, counting it against the cap
+// and the window limit of the minter
+func (k SupplyKeeper) AuthorizedMint(ctx sdk.Context, minter sdk.Address, amt types.Coin) sdk.Error {
+	authority, found := k.GetAuthority(ctx, minter)
+	if !found || !authority.HasRole(types.AUTHORITY_MINTER) {
+		return sdk.ErrUnauthorized(fmt.Sprintf(constants.AUTHORITY_NOT_GRANTED, minter, types.AUTHORITY_MINTER))
+	}
+
+	if err := authority.RecordMint(amt, ctx.BlockHeight(), constants.AUTHORITY_MINT_WINDOW); err != nil {
+		return sdk.ErrUnauthorized(err.Error())
+	}
+
+	k.setAuthority(ctx, authority)
+	k.Mint(ctx, amt)
+	return nil
+}
+
+// AuthorizedBurn - burn amt on behalf of burner
+func (k SupplyKeeper) AuthorizedBurn(ctx sdk.Context, burner sdk.Address, amt types.Coin) sdk.Error {
+	if !k.HasRole(ctx, burner, types.AUTHORITY_BURNER) {
+		return sdk.ErrUnauthorized(fmt.Sprintf(constants.AUTHORITY_NOT_GRANTED, burner, types.AUTHORITY_BURNER))
+	}
+
+	k.Burn(ctx, amt)
+	return nil
+}
+
+//------------------------------------------------------------------
+// Admins
+
+// AdminSet - accounts approving authority changes. A change is applied once
+// Threshold of them approved it.
+type AdminSet struct {
+	Admins    []sdk.Address `json:"admins"`
+	Threshold int           `json:"threshold"`
+}
+
+// GetAdmins - current authority admins
+func (k SupplyKeeper) GetAdmins(ctx sdk.Context) AdminSet {
+	var admins AdminSet
+	if err := utils.Retrieve(ctx.KVStore(k.storeKey), AdminKey, &admins); err != nil {
+		panic(err)
+	}
+	return admins
+}
+
+func (k SupplyKeeper) setAdmins(ctx sdk.Context, admins AdminSet) {
+	if err := utils.Store(ctx.KVStore(k.storeKey), AdminKey, admins); err != nil {
+		panic(err)
+	}
+}
+
+// IsAdmin - whether addr is one of the current authority admins
+func (s AdminSet) IsAdmin(addr sdk.Address) bool {
+	for _, a := range s.Admins {
+		if bytes.Equal(a, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+//------------------------------------------------------------------
+// Proposals
+
+// AuthorityProposal - authority change waiting for the approval of the admins.
+// Only approvals of current admins count toward the threshold. Proposals left
+// unapproved for AUTHORITY_PROPOSAL_TTL blocks expire.
+type AuthorityProposal struct {
+	ID        int64                 `json:"id"`
+	Change    types.AuthorityChange `json:"change"`
+	Proposer  sdk.Address           `json:"proposer"`
+	Approvals []sdk.Address         `json:"approvals"`
+	Height    int64                 `json:"height"` // height of the proposal
+	Executed  bool                  `json:"executed"`
+}
+
+// IsExpired - whether the proposal can no longer be approved at height
+func (p AuthorityProposal) IsExpired(height int64) bool {
+	return height > p.Height+constants.AUTHORITY_PROPOSAL_TTL
+}
+
+// HasApproved - whether addr approved the proposal
+func (p AuthorityProposal) HasApproved(addr sdk.Address) bool {
+	for _, a := range p.Approvals {
+		if bytes.Equal(a, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetProposal - authority change proposal with id
+func (k SupplyKeeper) GetProposal(ctx sdk.Context, id int64) (AuthorityProposal, bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(GetProposalKey(id))
+	if bz == nil {
+		return AuthorityProposal{}, false
+	}
+
+	var proposal AuthorityProposal
+	if err := json.Unmarshal(bz, &proposal); err != nil {
+		panic(err)
+	}
+	return proposal, true
+}
+
+func (k SupplyKeeper) setProposal(ctx sdk.Context, proposal AuthorityProposal) {
+	if err := utils.Store(ctx.KVStore(k.storeKey), GetProposalKey(proposal.ID), proposal); err != nil {
+		panic(err)
+	}
+}
+
+// nextProposalID - reserve the id of a new proposal
+func (k SupplyKeeper) nextProposalID(ctx sdk.Context) int64 {
+	store := ctx.KVStore(k.storeKey)
+
+	var id int64
+	if bz := store.Get(ProposalSequenceKey); bz != nil {
+		id = int64(binary.BigEndian.Uint64(bz))
+	}
+
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(id+1))
+	store.Set(ProposalSequenceKey, bz)
+
+	return id
+}
+
+// ProposeChange - open a proposal for change on behalf of the admin proposer.
+// The proposal counts as the approval of proposer, so the change is applied
+// right away when the threshold is one.
+func (k SupplyKeeper) ProposeChange(
+	ctx sdk.Context,
+	proposer sdk.Address,
+	change types.AuthorityChange,
+) (AuthorityProposal, sdk.Error) {
+	if !k.GetAdmins(ctx).IsAdmin(proposer) {
+		return AuthorityProposal{}, sdk.ErrUnauthorized(fmt.Sprintf(constants.AUTHORITY_NOT_ADMIN, proposer))
+	}
+
+	if change.Action == types.AUTHORITY_REMOVE {
+		if _, found := k.GetAuthority(ctx, change.Authority.Address); !found {
+			return AuthorityProposal{}, sdk.ErrUnknownAddress(fmt.Sprintf(constants.AUTHORITY_NOT_FOUND, change.Authority.Address))
+		}
+	}
+
+	proposal := AuthorityProposal{
+		ID:        k.nextProposalID(ctx),
+		Change:    change,
+		Proposer:  proposer,
+		Approvals: []sdk.Address{proposer},
+		Height:    ctx.BlockHeight(),
+	}
+
+	k.tryExecute(ctx, &proposal)
+	k.setProposal(ctx, proposal)
+
+	return proposal, nil
+}
+
+// ApproveChange - approve the proposal id on behalf of the admin approver,
+// applying its change once the threshold is reached
+func (k SupplyKeeper) ApproveChange(ctx sdk.Context, approver sdk.Address, id int64) (AuthorityProposal, sdk.Error) {
+	if !k.GetAdmins(ctx).IsAdmin(approver) {
+		return AuthorityProposal{}, sdk.ErrUnauthorized(fmt.Sprintf(constants.AUTHORITY_NOT_ADMIN, approver))
+	}
+
+	proposal, found := k.GetProposal(ctx, id)
+	if !found {
+		return proposal, sdk.ErrUnknownRequest(fmt.Sprintf(constants.AUTHORITY_PROPOSAL_NOT_FOUND, id))
+	}
+
+	if proposal.Executed || proposal.IsExpired(ctx.BlockHeight()) {
+		return proposal, sdk.ErrUnknownRequest(fmt.Sprintf(constants.AUTHORITY_PROPOSAL_CLOSED, id))
+	}
+
+	if proposal.HasApproved(approver) {
+		return proposal, sdk.ErrUnknownRequest(fmt.Sprintf(constants.AUTHORITY_ALREADY_APPROVED, approver, id))
+	}
+
+	proposal.Approvals = append(proposal.Approvals, approver)
+
+	k.tryExecute(ctx, &proposal)
+	k.setProposal(ctx, proposal)
+
+	return proposal, nil
+}
+
+// tryExecute - apply the change of proposal if enough current admins approved it
+func (k SupplyKeeper) tryExecute(ctx sdk.Context, proposal *AuthorityProposal) {
+	admins := k.GetAdmins(ctx)
+
+	approvals := 0
+	for _, a := range proposal.Approvals {
+		if admins.IsAdmin(a) {
+			approvals++
+		}
+	}
+
+	if approvals < admins.Threshold {
+		return
+	}
+
+	k.applyChange(ctx, proposal.Change)
+	proposal.Executed = true
+}
+
+// applyChange - apply an approved authority change. Setting an existing
+// authority keeps what it already minted.
+func (k SupplyKeeper) applyChange(ctx sdk.Context, change types.AuthorityChange) {
+	switch change.Action {
+	case types.AUTHORITY_SET:
+		authority := change.Authority
+		if existing, found := k.GetAuthority(ctx, authority.Address); found {
+			authority.Minted = existing.Minted
+			authority.WindowStart = existing.WindowStart
+			authority.WindowMinted = existing.WindowMinted
+		}
+		k.setAuthority(ctx, authority)
+	case types.AUTHORITY_REMOVE:
+		k.removeAuthority(ctx, change.Authority.Address)
+	case types.AUTHORITY_SET_ADMINS:
+		k.setAdmins(ctx, AdminSet{
+			Admins:    change.Admins,
+			Threshold: change.Threshold,
+		})
+	}
+}
@@ -10,5 +10,11 @@ func RegisterCodec(cdc *wire.Codec) *wire.Codec {
 	cdc.RegisterConcrete(msg.MsgCheck{}, "shareledger/bank/MsgCheck", nil)
 	cdc.RegisterConcrete(msg.MsgLoad{}, "shareledger/bank/MsgLoad", nil)
 	cdc.RegisterConcrete(msg.MsgBurn{}, "shareledger/bank/MsgBurn", nil)
+	cdc.RegisterConcrete(msg.MsgMultiSend{}, "shareledger/bank/MsgMultiSend", nil)
+	cdc.RegisterConcrete(msg.MsgProposeAuthorityChange{}, "shareledger/bank/MsgProposeAuthorityChange", nil)
+	cdc.RegisterConcrete(msg.MsgApproveAuthorityChange{}, "shareledger/bank/MsgApproveAuthorityChange", nil)
+	cdc.RegisterConcrete(msg.MsgGrantAllowance{}, "shareledger/bank/MsgGrantAllowance", nil)
+	cdc.RegisterConcrete(msg.MsgRevokeAllowance{}, "shareledger/bank/MsgRevokeAllowance", nil)
+	cdc.RegisterConcrete(msg.MsgSendFrom{}, "shareledger/bank/MsgSendFrom", nil)
 	return cdc
 }
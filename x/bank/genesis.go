@@ -0,0 +1,80 @@
+package bank
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+)
+
+// GenesisState - supply authorities and their admins provided at genesis
+type GenesisState struct {
+	Authorities []types.Authority `json:"authorities"`
+	Admins      []sdk.Address     `json:"admins"`
+	Threshold   int               `json:"threshold"`
+}
+
+// DefaultGenesisState - bank section of generated genesis files. DEFAULT_RESERVE
+// is a minter, burner and reserve without limits, and the only admin.
+func DefaultGenesisState() GenesisState {
+	reserve := utils.StringToAddress(constants.DEFAULT_RESERVE)
+
+	return GenesisState{
+		Authorities: []types.Authority{types.NewAuthority(
+			reserve,
+			[]string{types.AUTHORITY_MINTER, types.AUTHORITY_BURNER, types.AUTHORITY_RESERVE},
+			nil,
+			nil,
+		)},
+		Admins:    []sdk.Address{reserve},
+		Threshold: 1,
+	}
+}
+
+// ValidateGenesis - every authority and the admin set are valid, and
+// DEFAULT_RESERVE holds the reserve role that fee exchanges require
+func ValidateGenesis(data GenesisState) error {
+	reserve := utils.StringToAddress(constants.DEFAULT_RESERVE)
+	hasReserve := false
+
+	for _, authority := range data.Authorities {
+		if !authority.IsValid() {
+			return fmt.Errorf(constants.AUTHORITY_INVALID_GENESIS, authority.Address)
+		}
+		if bytes.Equal(authority.Address, reserve) && authority.HasRole(types.AUTHORITY_RESERVE) {
+			hasReserve = true
+		}
+	}
+
+	if !types.IsValidAdminSet(data.Admins, data.Threshold) {
+		return fmt.Errorf(constants.AUTHORITY_INVALID_GENESIS, "invalid admins or threshold")
+	}
+
+	if !hasReserve {
+		return fmt.Errorf(constants.AUTHORITY_INVALID_GENESIS, "default reserve "+constants.DEFAULT_RESERVE+" is not a reserve")
+	}
+
+	return nil
+}
+
+// InitGenesis - store the authorities and admins of the bank section of the
+// genesis file
+func InitGenesis(ctx sdk.Context, k SupplyKeeper, data GenesisState) error {
+	if err := ValidateGenesis(data); err != nil {
+		return err
+	}
+
+	for _, authority := range data.Authorities {
+		k.setAuthority(ctx, authority)
+	}
+
+	k.setAdmins(ctx, AdminSet{
+		Admins:    data.Admins,
+		Threshold: data.Threshold,
+	})
+	return nil
+}
@@ -0,0 +1,46 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+)
+
+func TestInitGenesis(t *testing.T) {
+	in := setupBankTest(t)
+	reserve := utils.StringToAddress(constants.DEFAULT_RESERVE)
+
+	// Authorities and admins come from the genesis file only
+	require.NotNil(t, InitGenesis(in.ctx, in.sk, GenesisState{}))
+	require.Len(t, in.sk.GetAuthorities(in.ctx), 0)
+
+	// Fees are exchanged against DEFAULT_RESERVE, which must be a reserve
+	noReserve := GenesisState{
+		Authorities: []types.Authority{types.NewAuthority(sender, []string{types.AUTHORITY_MINTER}, nil, nil)},
+		Admins:      []sdk.Address{sender},
+		Threshold:   1,
+	}
+	require.NotNil(t, InitGenesis(in.ctx, in.sk, noReserve))
+
+	data := noReserve
+	data.Authorities = append(data.Authorities, types.NewAuthority(reserve, []string{types.AUTHORITY_RESERVE}, nil, nil))
+	require.Nil(t, InitGenesis(in.ctx, in.sk, data))
+
+	require.True(t, in.sk.IsReserve(in.ctx, reserve))
+	require.True(t, in.sk.HasRole(in.ctx, sender, types.AUTHORITY_MINTER))
+	require.False(t, in.sk.IsReserve(in.ctx, sender))
+	require.True(t, in.sk.GetAdmins(in.ctx).IsAdmin(sender))
+	require.False(t, in.sk.GetAdmins(in.ctx).IsAdmin(reserve))
+}
+
+func TestDefaultGenesisState(t *testing.T) {
+	data := DefaultGenesisState()
+	require.Nil(t, ValidateGenesis(data))
+	require.Equal(t, []sdk.Address{utils.StringToAddress(constants.DEFAULT_RESERVE)}, data.Admins)
+}
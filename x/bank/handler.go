@@ -1,17 +1,20 @@
 package bank
 
 import (
+	"fmt"
 	"reflect"
 
 	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
 
 	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/utils"
 	"github.com/sharering/shareledger/x/auth"
 	"github.com/sharering/shareledger/x/bank/handlers"
 	"github.com/sharering/shareledger/x/bank/messages"
+	"github.com/sharering/shareledger/x/bank/tags"
 )
 
-func NewHandler(am auth.AccountMapper) sdk.Handler {
+func NewHandler(am auth.AccountMapper, sk SupplyKeeper, ak AllowanceKeeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		constants.LOGGER.Info(
 			"Msg for Bank Module",
@@ -23,14 +26,97 @@ func NewHandler(am auth.AccountMapper) sdk.Handler {
 		case messages.MsgCheck:
 			return handlers.HandleMsgCheck(am)(ctx, msg)
 		case messages.MsgLoad:
-			return handlers.HandleMsgLoad(am)(ctx, msg)
-		case messages.MsgSend:
+			return handlers.HandleMsgLoad(am, sk)(ctx, msg)
+		case messages.MsgSend, messages.MsgMultiSend:
 			return handlers.HandleMsgSend(am)(ctx, msg)
 		case messages.MsgBurn:
-			return handlers.HandleMsgBurn(am)(ctx, msg)
+			return handlers.HandleMsgBurn(am, sk)(ctx, msg)
+		case messages.MsgProposeAuthorityChange:
+			return handleProposeAuthorityChange(ctx, sk, msg)
+		case messages.MsgApproveAuthorityChange:
+			return handleApproveAuthorityChange(ctx, sk, msg)
+		case messages.MsgGrantAllowance:
+			return handleGrantAllowance(ctx, ak, msg)
+		case messages.MsgRevokeAllowance:
+			return handleRevokeAllowance(ctx, ak, msg)
+		case messages.MsgSendFrom:
+			return handlers.HandleMsgSendFrom(am, ak)(ctx, msg)
 		default:
 			errMsg := "Unrecognized bank Msg type" + reflect.TypeOf(msg).Name()
 			return sdk.ErrUnknownRequest(errMsg).Result()
 		}
 	}
 }
+
+func handleProposeAuthorityChange(ctx sdk.Context, sk SupplyKeeper, msg messages.MsgProposeAuthorityChange) sdk.Result {
+	signer := auth.GetSigner(ctx)
+
+	proposal, err := sk.ProposeChange(ctx, signer.GetAddress(), msg.Change)
+	if err != nil {
+		return err.Result()
+	}
+
+	return authorityProposalResult(msg, msg.Tags(), proposal)
+}
+
+func handleApproveAuthorityChange(ctx sdk.Context, sk SupplyKeeper, msg messages.MsgApproveAuthorityChange) sdk.Result {
+	signer := auth.GetSigner(ctx)
+
+	proposal, err := sk.ApproveChange(ctx, signer.GetAddress(), msg.ProposalID)
+	if err != nil {
+		return err.Result()
+	}
+
+	return authorityProposalResult(msg, msg.Tags(), proposal)
+}
+
+// authorityProposalResult - result of a proposal or an approval, tagged with
+// AuthorityChanged once the change was applied
+func authorityProposalResult(msg sdk.Msg, msgTags sdk.Tags, proposal AuthorityProposal) sdk.Result {
+	if proposal.Executed {
+		msgTags = msgTags.AppendTag(tags.Event, tags.AuthorityChanged)
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log:       fmt.Sprintf("{\"proposal\":%d, \"approvals\":%d, \"executed\":%t}", proposal.ID, len(proposal.Approvals), proposal.Executed),
+		Tags:      msgTags,
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+func handleGrantAllowance(ctx sdk.Context, ak AllowanceKeeper, msg messages.MsgGrantAllowance) sdk.Result {
+	signer := auth.GetSigner(ctx)
+
+	allowance, err := ak.GrantAllowance(ctx, signer.GetAddress(), msg.Spender, msg.Limit, msg.Expiration)
+	if err != nil {
+		return err.Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log:       fmt.Sprintf("{\"limit\":%s, \"expiration\":%d}", allowance.Limit, allowance.Expiration),
+		Tags:      msg.Tags().AppendTag(tags.FromAddress, []byte(signer.GetAddress().String())),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+func handleRevokeAllowance(ctx sdk.Context, ak AllowanceKeeper, msg messages.MsgRevokeAllowance) sdk.Result {
+	signer := auth.GetSigner(ctx)
+
+	if err := ak.RevokeAllowance(ctx, signer.GetAddress(), msg.Spender); err != nil {
+		return err.Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Tags:      msg.Tags().AppendTag(tags.FromAddress, []byte(signer.GetAddress().String())),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
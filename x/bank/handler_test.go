@@ -0,0 +1,177 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/abci/types"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	"bitbucket.org/shareringvn/cosmos-sdk/store"
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"bitbucket.org/shareringvn/cosmos-sdk/wire"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank/messages"
+	"github.com/sharering/shareledger/x/bank/tags"
+)
+
+var (
+	senderPub, _ = types.GenerateKeyPair()
+	alicePub, _  = types.GenerateKeyPair()
+	bobPub, _    = types.GenerateKeyPair()
+	sender       = senderPub.Address()
+	alice        = alicePub.Address()
+	bob          = bobPub.Address()
+
+	now = int64(1000000)
+)
+
+type testInput struct {
+	ctx     sdk.Context
+	am      auth.AccountMapper
+	sk      SupplyKeeper
+	ak      AllowanceKeeper
+	handler sdk.Handler
+}
+
+func makeTestCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	cdc.RegisterInterface((*auth.BaseAccount)(nil), nil)
+	cdc.RegisterConcrete(&auth.SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterInterface((*types.PubKey)(nil), nil)
+	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
+	return cdc
+}
+
+func setupBankTest(t *testing.T) testInput {
+	constants.LOGGER = log.NewNopLogger()
+
+	db := dbm.NewMemDB()
+	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(authKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(bankKey, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1, Time: now}, false, log.NewNopLogger())
+
+	am := auth.NewAccountMapper(makeTestCodec(), authKey, &auth.SHRAccount{})
+	sk := NewSupplyKeeper(bankKey)
+	ak := NewAllowanceKeeper(bankKey)
+
+	// Sender starts with 100 SHRP and 50 SHR
+	acc := auth.NewSHRAccountWithAddress(sender)
+	acc.SetCoins(acc.Coins.Plus(types.NewCoin(constants.BOOKING_DENOM, 100)).
+		Plus(types.NewCoin(constants.POS_DENOM, 50)))
+	am.SetAccount(ctx, acc)
+
+	return testInput{
+		ctx:     ctx,
+		am:      am,
+		sk:      sk,
+		ak:      ak,
+		handler: NewHandler(am, sk, ak),
+	}
+}
+
+func (in testInput) signedBy(addr sdk.Address) sdk.Context {
+	return auth.WithSigners(in.ctx, auth.NewSHRAccountWithAddress(addr))
+}
+
+func (in testInput) balance(addr sdk.Address, denom string) types.Coin {
+	acc := in.am.GetAccount(in.ctx, addr)
+	if acc == nil {
+		return types.NewCoin(denom, 0)
+	}
+	return acc.GetCoins().GetCoin(denom)
+}
+
+func TestMultiSend(t *testing.T) {
+	in := setupBankTest(t)
+
+	outputs := []messages.Output{
+		messages.NewOutput(alice, types.Coins{
+			types.NewCoin(constants.BOOKING_DENOM, 10),
+			types.NewCoin(constants.POS_DENOM, 5),
+		}),
+		messages.NewOutput(bob, types.Coins{types.NewCoin(constants.BOOKING_DENOM, 20)}),
+	}
+	msg := messages.NewMsgMultiSend(sender, outputs)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, []sdk.Address{sender}, msg.GetSigners())
+
+	// Only the sender can sign
+	res := in.handler(in.signedBy(alice), msg)
+	require.False(t, res.IsOK())
+
+	res = in.handler(in.signedBy(sender), msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	sendFee, _ := utils.GetMsgFee(messages.MsgSend{})
+	require.Equal(t, 2*sendFee, res.FeeAmount)
+
+	require.True(t, in.balance(sender, constants.BOOKING_DENOM).Equal(types.NewCoin(constants.BOOKING_DENOM, 70)))
+	require.True(t, in.balance(sender, constants.POS_DENOM).Equal(types.NewCoin(constants.POS_DENOM, 45)))
+	require.True(t, in.balance(alice, constants.BOOKING_DENOM).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)))
+	require.True(t, in.balance(alice, constants.POS_DENOM).Equal(types.NewCoin(constants.POS_DENOM, 5)))
+	require.True(t, in.balance(bob, constants.BOOKING_DENOM).Equal(types.NewCoin(constants.BOOKING_DENOM, 20)))
+
+	// Each recipient is tagged with its amount
+	var recipients, amounts []string
+	for _, tag := range res.Tags {
+		switch string(tag.Key) {
+		case tags.ToAddress:
+			recipients = append(recipients, string(tag.Value))
+		case tags.Amount:
+			amounts = append(amounts, string(tag.Value))
+		}
+	}
+	require.Equal(t, []string{alice.String(), bob.String()}, recipients)
+	require.Equal(t, []string{outputs[0].Amount.String(), outputs[1].Amount.String()}, amounts)
+}
+
+func TestMultiSendIsAtomic(t *testing.T) {
+	in := setupBankTest(t)
+
+	// Every output is affordable alone but not their total
+	outputs := []messages.Output{
+		messages.NewOutput(alice, types.Coins{types.NewCoin(constants.BOOKING_DENOM, 60)}),
+		messages.NewOutput(bob, types.Coins{types.NewCoin(constants.BOOKING_DENOM, 60)}),
+	}
+	res := in.handler(in.signedBy(sender), messages.NewMsgMultiSend(sender, outputs))
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInsufficientCoins), res.Code)
+
+	require.True(t, in.balance(sender, constants.BOOKING_DENOM).Equal(types.NewCoin(constants.BOOKING_DENOM, 100)))
+	require.True(t, in.balance(alice, constants.BOOKING_DENOM).IsZero())
+	require.True(t, in.balance(bob, constants.BOOKING_DENOM).IsZero())
+}
+
+func TestMultiSendValidation(t *testing.T) {
+	out := messages.NewOutput(alice, types.Coins{types.NewCoin(constants.BOOKING_DENOM, 1)})
+
+	require.NotNil(t, messages.NewMsgMultiSend(nil, []messages.Output{out}).ValidateBasic())
+	require.NotNil(t, messages.NewMsgMultiSend(sender, nil).ValidateBasic())
+	require.NotNil(t, messages.NewMsgMultiSend(sender, make([]messages.Output, constants.BANK_MAX_OUTPUTS+1)).ValidateBasic())
+	require.NotNil(t, messages.NewMsgMultiSend(sender, []messages.Output{
+		messages.NewOutput(alice, types.Coins{types.NewCoin("XYZ", 1)}),
+	}).ValidateBasic())
+	require.NotNil(t, messages.NewMsgMultiSend(sender, []messages.Output{
+		messages.NewOutput(alice, types.Coins{types.NewCoin(constants.BOOKING_DENOM, 0)}),
+	}).ValidateBasic())
+	require.NotNil(t, messages.NewMsgMultiSend(sender, []messages.Output{
+		messages.NewOutput(alice, types.Coins{
+			types.NewCoin(constants.BOOKING_DENOM, 1),
+			types.NewCoin(constants.BOOKING_DENOM, 2),
+		}),
+	}).ValidateBasic())
+	require.NotNil(t, messages.NewMsgMultiSend(sender, []messages.Output{
+		messages.NewOutput(alice, nil),
+	}).ValidateBasic())
+}
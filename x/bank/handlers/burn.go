@@ -7,7 +7,6 @@ import (
 	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
 
 	"github.com/sharering/shareledger/constants"
-	"github.com/sharering/shareledger/utils"
 	"github.com/sharering/shareledger/x/auth"
 	"github.com/sharering/shareledger/x/bank/messages"
 )
@@ -15,20 +14,18 @@ import (
 //--------------------------------
 // Handler for the message
 
-func HandleMsgBurn(am auth.AccountMapper) sdk.Handler {
+func HandleMsgBurn(am auth.AccountMapper, supply Supply) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		burnMsg, ok := msg.(messages.MsgBurn)
 		if !ok {
 			return sdk.NewError(2, 1, "MsgBurn is malformed").Result()
 		}
 
-		// IMPORTANT
-		// TODO: require a list of limited accounts which are priviledged to load coins
 		signer := auth.GetSigner(ctx)
 
-		// Only reserve is allowed to execute this function
-		if !utils.IsValidReserve(signer.GetAddress()) {
-			return sdk.ErrInternal(fmt.Sprintf(constants.RES_RESERVE_ONLY)).Result()
+		// Only burners are allowed to execute this function
+		if err := supply.AuthorizedBurn(ctx, signer.GetAddress(), burnMsg.Amount); err != nil {
+			return err.Result()
 		}
 
 		if !bytes.Equal(signer.GetAddress(), burnMsg.Account) {
@@ -41,6 +38,7 @@ func HandleMsgBurn(am auth.AccountMapper) sdk.Handler {
 		if resT = handleFrom(ctx, am, burnMsg.Account, burnMsg.Amount); !resT.IsOK() {
 			return resT
 		}
+
 		return sdk.Result{
 			Log:  resT.Log,
 			Data: resT.Data,
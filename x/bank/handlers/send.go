@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"bytes"
 	"fmt"
 
 	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
 
+	"github.com/sharering/shareledger/constants"
 	"github.com/sharering/shareledger/types"
 	"github.com/sharering/shareledger/utils"
 	"github.com/sharering/shareledger/x/auth"
@@ -15,11 +17,15 @@ import (
 //------------------------------------------------------------------
 // Handler for the message
 
-// Handle MsgSend.
+// Handle MsgSend and MsgMultiSend.
 // NOTE: msg.From, msg.To, and msg.Amount were already validated
 // in ValidateBasic().
 func HandleMsgSend(am auth.AccountMapper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		if multiMsg, ok := msg.(messages.MsgMultiSend); ok {
+			return handleMultiSend(ctx, am, multiMsg)
+		}
+
 		sendMsg, ok := msg.(messages.MsgSend)
 
 		if !ok {
@@ -60,6 +66,39 @@ func HandleMsgSend(am auth.AccountMapper) sdk.Handler {
 	}
 }
 
+// handleMultiSend - debit the total of every output from the signer, then credit
+// each output. The balance is checked against the total before anything is
+// written so either every output is credited or no balance changes. A failed
+// credit fails the message and its writes are discarded.
+func handleMultiSend(ctx sdk.Context, am auth.AccountMapper, msg messages.MsgMultiSend) sdk.Result {
+	signer := auth.GetSigner(ctx)
+	if signer == nil || !bytes.Equal(signer.GetAddress(), msg.From) {
+		return sdk.ErrUnauthorized(fmt.Sprintf(constants.BANK_NOT_SENDER, msg.From)).Result()
+	}
+
+	if resF := handleFromMany(ctx, am, signer.GetAddress(), msg.Total()); !resF.IsOK() {
+		return resF
+	}
+
+	for _, out := range msg.Outputs {
+		for _, amt := range out.Amount {
+			if resT := handleTo(ctx, am, out.To, amt); !resT.IsOK() {
+				return resT
+			}
+		}
+	}
+
+	// Each output pays the fee of a MsgSend
+	fee, denom := utils.GetMsgFee(messages.MsgSend{})
+
+	return sdk.Result{
+		Log:       fmt.Sprintf("{\"from\":%v, \"outputs\":%d}", am.GetAccount(ctx, signer.GetAddress()).GetCoins(), len(msg.Outputs)),
+		Tags:      msg.Tags().AppendTag(tags.FromAddress, []byte(signer.GetAddress().String())),
+		FeeAmount: fee * int64(len(msg.Outputs)),
+		FeeDenom:  denom,
+	}
+}
+
 // Convenience Handlers
 func handleFrom(ctx sdk.Context, am auth.AccountMapper, from sdk.Address, amt types.Coin) sdk.Result {
 
@@ -81,6 +120,12 @@ func handleFrom(ctx sdk.Context, am auth.AccountMapper, from sdk.Address, amt ty
 		return sdk.ErrInsufficientCoins("Insufficient coins in account").Result()
 	}
 
+	// Coins locked by vesting cannot be spent
+	spendable := auth.SpendableCoins(acc, ctx.BlockHeader().Time)
+	if !spendable.Minus(amt).IsNotNegative() {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf(constants.VESTING_INSUFFICIENT_SPENDABLE, spendable, amt)).Result()
+	}
+
 	// Set acc coins to new amount.
 	acc.SetCoins(senderCoinsAfter)
 
@@ -90,6 +135,35 @@ func handleFrom(ctx sdk.Context, am auth.AccountMapper, from sdk.Address, amt ty
 	return sdk.Result{Log: acc.GetCoins().String()}
 }
 
+func handleFromMany(ctx sdk.Context, am auth.AccountMapper, from sdk.Address, amt types.Coins) sdk.Result {
+
+	acc := am.GetAccount(ctx, from)
+
+	// In case there is no associate account
+	if acc == nil {
+		shrAcc := auth.NewSHRAccountWithAddress(from)
+		acc = shrAcc
+	}
+
+	senderCoinsAfter := acc.GetCoins().MinusMany(amt)
+
+	// If any coin has negative amount, return insufficient coins error.
+	if !senderCoinsAfter.IsNotNegative() {
+		return sdk.ErrInsufficientCoins("Insufficient coins in account").Result()
+	}
+
+	// Coins locked by vesting cannot be spent
+	spendable := auth.SpendableCoins(acc, ctx.BlockHeader().Time)
+	if !spendable.MinusMany(amt).IsNotNegative() {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf(constants.VESTING_INSUFFICIENT_SPENDABLE, spendable, amt)).Result()
+	}
+
+	acc.SetCoins(senderCoinsAfter)
+	am.SetAccount(ctx, acc)
+
+	return sdk.Result{Log: acc.GetCoins().String()}
+}
+
 func handleTo(ctx sdk.Context, am auth.AccountMapper, to sdk.Address, amt types.Coin) sdk.Result {
 	// Add msg amount to receiver account
 	acc := am.GetAccount(ctx, to)
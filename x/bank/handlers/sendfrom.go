@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank/messages"
+	tags "github.com/sharering/shareledger/x/bank/tags"
+)
+
+// Allowances - allowances spent when coins are sent on behalf of their owner
+type Allowances interface {
+	UseAllowance(ctx sdk.Context, granter sdk.Address, grantee sdk.Address, amt types.Coins) sdk.Error
+}
+
+//--------------------------------
+// Handler for the message
+
+// HandleMsgSendFrom - the signer sends coins of msg.From within the allowance
+// msg.From granted to the signer. The signer pays the fee.
+func HandleMsgSendFrom(am auth.AccountMapper, allowances Allowances) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		sendMsg, ok := msg.(messages.MsgSendFrom)
+		if !ok {
+			return sdk.NewError(2, 1, "MsgSendFrom is malformed").Result()
+		}
+
+		signer := auth.GetSigner(ctx)
+
+		if err := allowances.UseAllowance(ctx, sendMsg.From, signer.GetAddress(), sendMsg.Amount); err != nil {
+			return err.Result()
+		}
+
+		var resF sdk.Result
+		if resF = handleFromMany(ctx, am, sendMsg.From, sendMsg.Amount); !resF.IsOK() {
+			return resF
+		}
+
+		for _, amt := range sendMsg.Amount {
+			if resT := handleTo(ctx, am, sendMsg.To, amt); !resT.IsOK() {
+				return resT
+			}
+		}
+
+		fee, denom := utils.GetMsgFee(msg)
+
+		return sdk.Result{
+			Log:       resF.Log,
+			Tags:      sendMsg.Tags().AppendTag(tags.Spender, []byte(signer.GetAddress().String())),
+			FeeAmount: fee,
+			FeeDenom:  denom,
+		}
+	}
+}
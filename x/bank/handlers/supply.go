@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/types"
+)
+
+// Supply - total supply updated when coins are loaded or burnt by a supply
+// authority
+type Supply interface {
+	AuthorizedMint(ctx sdk.Context, minter sdk.Address, amt types.Coin) sdk.Error
+	AuthorizedBurn(ctx sdk.Context, burner sdk.Address, amt types.Coin) sdk.Error
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
 	"github.com/sharering/shareledger/types"
 	"github.com/sharering/shareledger/x/auth"
 )
@@ -26,6 +27,14 @@ func (k Keeper) GetCoins(
 	return getCoins(ctx, k.am, addr)
 }
 
+// GetSpendableCoins - coins of addr that are not locked by vesting
+func (k Keeper) GetSpendableCoins(
+	ctx sdk.Context,
+	addr sdk.Address,
+) types.Coins {
+	return getSpendableCoins(ctx, k.am, addr)
+}
+
 func (k Keeper) SetCoins(
 	ctx sdk.Context,
 	addr sdk.Address,
@@ -70,6 +79,61 @@ func (k Keeper) AddCoin(
 	return addCoin(ctx, k.am, addr, amt)
 }
 
+// DelegateCoins - remove amt from addr to be staked. Coins locked by vesting
+// can be delegated, the account keeps track of them.
+func (k Keeper) DelegateCoins(
+	ctx sdk.Context,
+	addr sdk.Address,
+	amt types.Coins,
+) (types.Coins, sdk.Error) {
+
+	acc := k.am.GetAccount(ctx, addr)
+
+	if acc == nil {
+		return types.Coins{}, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", types.Coins{}, amt))
+	}
+
+	newCoins := acc.GetCoins().MinusMany(amt)
+
+	if !newCoins.IsNotNegative() {
+		return acc.GetCoins(), sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", acc.GetCoins(), amt))
+	}
+
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		vacc.TrackDelegation(ctx.BlockHeader().Time, amt)
+	}
+
+	acc.SetCoins(newCoins)
+	k.am.SetAccount(ctx, acc)
+
+	return newCoins, nil
+}
+
+// UndelegateCoins - give back amt unstaked to addr
+func (k Keeper) UndelegateCoins(
+	ctx sdk.Context,
+	addr sdk.Address,
+	amt types.Coins,
+) (types.Coins, sdk.Error) {
+
+	acc := k.am.GetAccount(ctx, addr)
+
+	if acc == nil {
+		acc = k.am.NewAccountWithAddress(ctx, addr)
+		acc.SetCoins(types.NewDefaultCoins())
+	}
+
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		vacc.TrackUndelegation(amt)
+	}
+
+	newCoins := acc.GetCoins().PlusMany(amt)
+
+	acc.SetCoins(newCoins)
+	k.am.SetAccount(ctx, acc)
+
+	return newCoins, nil
+}
 
 //-------------------------------------------------------------------------
 
@@ -92,6 +156,12 @@ func subtractCoin(
 		return oldCoins, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
 	}
 
+	// Coins locked by vesting cannot be spent
+	spendable := getSpendableCoins(ctx, am, addr)
+	if !spendable.Minus(amt).IsNotNegative() {
+		return oldCoins, sdk.ErrInsufficientCoins(fmt.Sprintf(constants.VESTING_INSUFFICIENT_SPENDABLE, spendable, amt))
+	}
+
 	err := setCoins(ctx, am, addr, newCoins)
 
 	return newCoins, err
@@ -113,6 +183,12 @@ func substractCoins(
 		return oldCoins, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
 	}
 
+	// Coins locked by vesting cannot be spent
+	spendable := getSpendableCoins(ctx, am, addr)
+	if !spendable.MinusMany(amt).IsNotNegative() {
+		return oldCoins, sdk.ErrInsufficientCoins(fmt.Sprintf(constants.VESTING_INSUFFICIENT_SPENDABLE, spendable, amt))
+	}
+
 	err := setCoins(ctx, am, addr, newCoins)
 
 	return newCoins, err
@@ -169,6 +245,17 @@ func getCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address) types.Co
 	return acc.GetCoins()
 }
 
+func getSpendableCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address) types.Coins {
+
+	acc := am.GetAccount(ctx, addr)
+
+	if acc == nil {
+		return types.Coins{}
+	}
+
+	return auth.SpendableCoins(acc, ctx.BlockHeader().Time)
+}
+
 func setCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address, amt types.Coins) sdk.Error {
 
 	acc := am.GetAccount(ctx, addr)
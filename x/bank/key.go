@@ -0,0 +1,65 @@
+package bank
+
+import (
+	"encoding/binary"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+)
+
+var (
+	SupplyKey           = []byte{0x01} // prefix for the total supply of each denom
+	AuthorityKey        = []byte{0x02} // prefix for the supply authorities
+	AdminKey            = []byte{0x03} // key for the admins approving authority changes
+	ProposalKey         = []byte{0x04} // prefix for authority change proposals
+	ProposalSequenceKey = []byte{0x05} // number of authority change proposals so far
+	AllowanceKey        = []byte{0x06} // prefix for allowances, by granter
+	GranteeIndexKey     = []byte{0x07} // prefix for allowances, by grantee
+)
+
+// gets the key of the total supply of denom
+// VALUE: types.Coin
+func GetSupplyKey(denom string) []byte {
+	return append(append([]byte{}, SupplyKey...), []byte(denom)...)
+}
+
+// gets the key of a supply authority
+// VALUE: types.Authority
+func GetAuthorityKey(addr sdk.Address) []byte {
+	return append(append([]byte{}, AuthorityKey...), addr...)
+}
+
+// gets the key of an authority change proposal
+// VALUE: AuthorityProposal
+func GetProposalKey(id int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(id))
+	return append(append([]byte{}, ProposalKey...), bz...)
+}
+
+// gets the prefix of every allowance granted by granter
+func GetAllowancePrefix(granter sdk.Address) []byte {
+	return append(append([]byte{}, AllowanceKey...), addressPrefix(granter)...)
+}
+
+// gets the key of the allowance granted by granter to grantee
+// VALUE: Allowance
+func GetAllowanceKey(granter sdk.Address, grantee sdk.Address) []byte {
+	return append(GetAllowancePrefix(granter), grantee...)
+}
+
+// gets the prefix of every allowance granted to grantee
+func GetGranteeIndexPrefix(grantee sdk.Address) []byte {
+	return append(append([]byte{}, GranteeIndexKey...), addressPrefix(grantee)...)
+}
+
+// gets the key of the allowance granted by granter in the index of grantee
+// VALUE: granter
+func GetGranteeIndexKey(grantee sdk.Address, granter sdk.Address) []byte {
+	return append(GetGranteeIndexPrefix(grantee), granter...)
+}
+
+// addressPrefix - addr preceded by its length so that no address is a prefix
+// of another
+func addressPrefix(addr sdk.Address) []byte {
+	return append([]byte{byte(len(addr))}, addr...)
+}
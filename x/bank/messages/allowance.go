@@ -0,0 +1,194 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	types "github.com/sharering/shareledger/types"
+	tags "github.com/sharering/shareledger/x/bank/tags"
+)
+
+//------------------------------------------------------------------
+// Msg
+
+// MsgGrantAllowance implements sdk.Msg
+var _ sdk.Msg = MsgGrantAllowance{}
+
+// MsgGrantAllowance - the signer allows Spender to send up to Limit of its
+// coins with MsgSendFrom until Expiration. A new grant replaces the previous
+// one.
+type MsgGrantAllowance struct {
+	Spender    sdk.Address `json:"spender"`
+	Limit      types.Coins `json:"limit"`
+	Expiration int64       `json:"expiration"` // unix time, 0 never expires
+}
+
+func NewMsgGrantAllowance(spender sdk.Address, limit types.Coins, expiration int64) MsgGrantAllowance {
+	return MsgGrantAllowance{spender, limit, expiration}
+}
+
+// Implements Msg.
+func (msg MsgGrantAllowance) Type() string { return constants.MESSAGE_BANK }
+
+// Implements Msg. Ensure the spender is set and the limit is positive in
+// distinct known denoms.
+func (msg MsgGrantAllowance) ValidateBasic() sdk.Error {
+	if len(msg.Spender) == 0 {
+		return sdk.ErrInvalidAddress("Spender address is empty")
+	}
+	if msg.Expiration < 0 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ALLOWANCE_INVALID_EXPIRATION, msg.Expiration))
+	}
+	return ValidateAmount(msg.Limit)
+}
+
+// Implements Msg. JSON encode the message.
+func (msg MsgGrantAllowance) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Implements Msg. The granter is deduced from the signature.
+func (msg MsgGrantAllowance) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+// Returns the sdk.Tags for the message
+func (msg MsgGrantAllowance) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.AllowanceGranted).
+		AppendTag(tags.Spender, []byte(msg.Spender.String())).
+		AppendTag(tags.Amount, []byte(msg.Limit.String())).
+		AppendTag(tags.Expiration, []byte(strconv.FormatInt(msg.Expiration, 10)))
+}
+
+//------------------------------------------------------------------
+
+// MsgRevokeAllowance implements sdk.Msg
+var _ sdk.Msg = MsgRevokeAllowance{}
+
+// MsgRevokeAllowance - the signer removes the allowance granted to Spender
+type MsgRevokeAllowance struct {
+	Spender sdk.Address `json:"spender"`
+}
+
+func NewMsgRevokeAllowance(spender sdk.Address) MsgRevokeAllowance {
+	return MsgRevokeAllowance{spender}
+}
+
+// Implements Msg.
+func (msg MsgRevokeAllowance) Type() string { return constants.MESSAGE_BANK }
+
+// Implements Msg.
+func (msg MsgRevokeAllowance) ValidateBasic() sdk.Error {
+	if len(msg.Spender) == 0 {
+		return sdk.ErrInvalidAddress("Spender address is empty")
+	}
+	return nil
+}
+
+// Implements Msg. JSON encode the message.
+func (msg MsgRevokeAllowance) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Implements Msg. The granter is deduced from the signature.
+func (msg MsgRevokeAllowance) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+// Returns the sdk.Tags for the message
+func (msg MsgRevokeAllowance) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.AllowanceRevoked).
+		AppendTag(tags.Spender, []byte(msg.Spender.String()))
+}
+
+//------------------------------------------------------------------
+
+// MsgSendFrom implements sdk.Msg
+var _ sdk.Msg = MsgSendFrom{}
+
+// MsgSendFrom - the signer sends Amount of the coins of From to To, within the
+// allowance From granted to the signer
+type MsgSendFrom struct {
+	From   sdk.Address `json:"from"`
+	To     sdk.Address `json:"to"`
+	Amount types.Coins `json:"amount"`
+}
+
+func NewMsgSendFrom(from sdk.Address, to sdk.Address, amt types.Coins) MsgSendFrom {
+	return MsgSendFrom{from, to, amt}
+}
+
+// Implements Msg.
+func (msg MsgSendFrom) Type() string { return constants.MESSAGE_BANK }
+
+// Implements Msg. Ensure the addresses are good and the amount is positive in
+// distinct known denoms.
+func (msg MsgSendFrom) ValidateBasic() sdk.Error {
+	if len(msg.From) == 0 {
+		return sdk.ErrInvalidAddress("From address is empty")
+	}
+	if len(msg.To) == 0 {
+		return sdk.ErrInvalidAddress("To address is empty")
+	}
+	return ValidateAmount(msg.Amount)
+}
+
+// Implements Msg. JSON encode the message.
+func (msg MsgSendFrom) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Implements Msg. The spender is deduced from the signature.
+func (msg MsgSendFrom) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+// Returns the sdk.Tags for the message
+func (msg MsgSendFrom) Tags() sdk.Tags {
+	return sdk.NewTags(tags.FromAddress, []byte(msg.From.String())).
+		AppendTag(tags.ToAddress, []byte(msg.To.String())).
+		AppendTag(tags.Amount, []byte(msg.Amount.String())).
+		AppendTag(tags.Event, tags.Transfered)
+}
+
+//------------------------------------------------------------------
+
+// ValidateAmount - amt is not empty and every coin is positive in a distinct
+// known denom
+func ValidateAmount(amt types.Coins) sdk.Error {
+	if len(amt) == 0 {
+		return sdk.ErrInvalidCoins("Amount is empty")
+	}
+
+	for i, c := range amt {
+		if !c.IsPositive() {
+			return sdk.ErrInvalidCoins("Amount is not positive")
+		}
+		if !types.IsValidDenom(c.Denom) {
+			return sdk.ErrInvalidCoins(fmt.Sprintf(constants.BANK_INVALID_DENOM, c.Denom))
+		}
+		for _, o := range amt[i+1:] {
+			if c.IsSameDenom(o) {
+				return sdk.ErrInvalidCoins(fmt.Sprintf(constants.BANK_DUPLICATE_DENOM, c.Denom))
+			}
+		}
+	}
+
+	return nil
+}
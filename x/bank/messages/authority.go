@@ -0,0 +1,107 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	types "github.com/sharering/shareledger/types"
+	tags "github.com/sharering/shareledger/x/bank/tags"
+)
+
+//------------------------------------------------------------------
+// Msg
+
+// MsgProposeAuthorityChange implements sdk.Msg
+var _ sdk.Msg = MsgProposeAuthorityChange{}
+
+// MsgProposeAuthorityChange - an admin proposes a change to the supply
+// authorities or to the admins. The proposal counts as the approval of its
+// proposer.
+type MsgProposeAuthorityChange struct {
+	Change types.AuthorityChange `json:"change"`
+}
+
+func NewMsgProposeAuthorityChange(change types.AuthorityChange) MsgProposeAuthorityChange {
+	return MsgProposeAuthorityChange{change}
+}
+
+// Implements Msg.
+func (msg MsgProposeAuthorityChange) Type() string { return constants.MESSAGE_BANK }
+
+// Implements Msg.
+func (msg MsgProposeAuthorityChange) ValidateBasic() sdk.Error {
+	if !msg.Change.IsValid() {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.AUTHORITY_INVALID_CHANGE, msg.Change.Action))
+	}
+	return nil
+}
+
+// Implements Msg. JSON encode the message.
+func (msg MsgProposeAuthorityChange) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Implements Msg. The proposer is deduced from the signature.
+func (msg MsgProposeAuthorityChange) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+// Returns the sdk.Tags for the message
+func (msg MsgProposeAuthorityChange) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.AuthorityProposed).
+		AppendTag(tags.Action, []byte(msg.Change.Action))
+}
+
+//------------------------------------------------------------------
+
+// MsgApproveAuthorityChange implements sdk.Msg
+var _ sdk.Msg = MsgApproveAuthorityChange{}
+
+// MsgApproveAuthorityChange - an admin approves an open proposal. The change
+// is applied once the proposal has the approvals of AdminThreshold admins.
+type MsgApproveAuthorityChange struct {
+	ProposalID int64 `json:"proposal_id"`
+}
+
+func NewMsgApproveAuthorityChange(proposalID int64) MsgApproveAuthorityChange {
+	return MsgApproveAuthorityChange{proposalID}
+}
+
+// Implements Msg.
+func (msg MsgApproveAuthorityChange) Type() string { return constants.MESSAGE_BANK }
+
+// Implements Msg.
+func (msg MsgApproveAuthorityChange) ValidateBasic() sdk.Error {
+	if msg.ProposalID < 0 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.AUTHORITY_PROPOSAL_NOT_FOUND, msg.ProposalID))
+	}
+	return nil
+}
+
+// Implements Msg. JSON encode the message.
+func (msg MsgApproveAuthorityChange) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Implements Msg. The approver is deduced from the signature.
+func (msg MsgApproveAuthorityChange) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+// Returns the sdk.Tags for the message
+func (msg MsgApproveAuthorityChange) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.AuthorityApproved).
+		AppendTag(tags.ProposalID, []byte(strconv.FormatInt(msg.ProposalID, 10)))
+}
@@ -0,0 +1,100 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	types "github.com/sharering/shareledger/types"
+	tags "github.com/sharering/shareledger/x/bank/tags"
+)
+
+//------------------------------------------------------------------
+// Msg
+
+// MsgMultiSend implements sdk.Msg
+var _ sdk.Msg = MsgMultiSend{}
+
+// Output - coins credited to one recipient of a MsgMultiSend
+type Output struct {
+	To     sdk.Address `json:"to"`
+	Amount types.Coins `json:"amount"`
+}
+
+func NewOutput(to sdk.Address, amt types.Coins) Output {
+	return Output{to, amt}
+}
+
+// MsgMultiSend to send coins of several denoms from From to several
+// recipients. Either every output is credited or none is.
+type MsgMultiSend struct {
+	From    sdk.Address `json:"from"`
+	Outputs []Output    `json:"outputs"`
+}
+
+// NewMsgMultiSend
+func NewMsgMultiSend(from sdk.Address, outputs []Output) MsgMultiSend {
+	return MsgMultiSend{from, outputs}
+}
+
+// Implements Msg.
+func (msg MsgMultiSend) Type() string { return constants.MESSAGE_BANK }
+
+// Implements Msg. Ensure there are between 1 and BANK_MAX_OUTPUTS outputs,
+// the addresses are good and every amount is positive in distinct known denoms.
+func (msg MsgMultiSend) ValidateBasic() sdk.Error {
+	if len(msg.From) == 0 {
+		return sdk.ErrInvalidAddress("From address is empty")
+	}
+
+	if len(msg.Outputs) == 0 || len(msg.Outputs) > constants.BANK_MAX_OUTPUTS {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.BANK_INVALID_OUTPUTS, constants.BANK_MAX_OUTPUTS, len(msg.Outputs)))
+	}
+
+	for _, out := range msg.Outputs {
+		if len(out.To) == 0 {
+			return sdk.ErrInvalidAddress("To address is empty")
+		}
+		if err := ValidateAmount(out.Amount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Total - sum of the amounts of every output. Coins.Plus only adds to denoms
+// already present so the sum starts from a zero coin of every denom.
+func (msg MsgMultiSend) Total() types.Coins {
+	total := types.NewDefaultCoins()
+	for _, out := range msg.Outputs {
+		total = total.PlusMany(out.Amount)
+	}
+	return total
+}
+
+// Implements Msg. JSON encode the message.
+func (msg MsgMultiSend) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Implements Msg. The sender signs.
+func (msg MsgMultiSend) GetSigners() []sdk.Address {
+	return []sdk.Address{msg.From}
+}
+
+// Returns the sdk.Tags for the message, one ToAddress and Amount per output
+func (msg MsgMultiSend) Tags() sdk.Tags {
+	t := sdk.NewTags(tags.Event, tags.Transfered)
+	for _, out := range msg.Outputs {
+		t = t.AppendTag(tags.ToAddress, []byte(out.To.String())).
+			AppendTag(tags.Amount, []byte(out.Amount.String()))
+	}
+	return t
+}
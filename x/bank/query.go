@@ -0,0 +1,182 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	wire "bitbucket.org/shareringvn/cosmos-sdk/wire"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+
+	abci "github.com/tendermint/abci/types"
+)
+
+// query endpoints supported by the bank Querier
+const (
+	QuerySupply      = "supply"
+	QueryAuthorities = "authorities"
+	QueryProposal    = "proposal"
+	QueryGranted     = "granted"
+	QueryReceived    = "received"
+)
+
+// creates a querier for bank REST endpoints
+func NewQuerier(k SupplyKeeper, ak AllowanceKeeper, cdc *wire.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QuerySupply:
+			return querySupply(ctx, cdc, req, k)
+		case QueryAuthorities:
+			return queryAuthorities(ctx, cdc, k)
+		case QueryProposal:
+			return queryProposal(ctx, cdc, req, k)
+		case QueryGranted, QueryReceived:
+			return queryAllowances(ctx, path[0], cdc, req, ak)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown bank query endpoint")
+		}
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/bank/supply'
+// An empty Denom returns the supply of every denom.
+type QuerySupplyParams struct {
+	Denom string
+}
+
+func querySupply(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k SupplyKeeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QuerySupplyParams
+
+	if len(req.Data) > 0 {
+		if errRes := cdc.UnmarshalBinary(req.Data, &params); errRes != nil {
+			return []byte{},
+				sdk.ErrUnknownRequest(fmt.Sprintf(constants.BANK_INVALID_PARAMS, errRes.Error()))
+		}
+	}
+
+	var errRes error
+	if len(params.Denom) == 0 {
+		res, errRes = cdc.MarshalJSON(k.GetTotalSupply(ctx))
+	} else {
+		if !constants.DENOM_LIST[params.Denom] {
+			return []byte{},
+				sdk.ErrInvalidCoins(fmt.Sprintf(constants.BANK_INVALID_DENOM, params.Denom))
+		}
+		res, errRes = cdc.MarshalJSON(k.GetSupply(ctx, params.Denom))
+	}
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.BANK_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
+
+// AuthoritiesResponse - supply authorities and their admins
+type AuthoritiesResponse struct {
+	Authorities []types.Authority `json:"authorities"`
+	Admins      AdminSet          `json:"admins"`
+}
+
+func queryAuthorities(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	k SupplyKeeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	res, errRes := cdc.MarshalJSON(AuthoritiesResponse{
+		Authorities: k.GetAuthorities(ctx),
+		Admins:      k.GetAdmins(ctx),
+	})
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.BANK_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
+
+// defines the params for the following queries:
+// - 'custom/bank/proposal'
+type QueryProposalParams struct {
+	ID int64
+}
+
+func queryProposal(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k SupplyKeeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryProposalParams
+
+	if errRes := cdc.UnmarshalBinary(req.Data, &params); errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.BANK_INVALID_PARAMS, errRes.Error()))
+	}
+
+	proposal, found := k.GetProposal(ctx, params.ID)
+	if !found {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.AUTHORITY_PROPOSAL_NOT_FOUND, params.ID))
+	}
+
+	res, errRes := cdc.MarshalJSON(proposal)
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.BANK_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
+
+// defines the params for the following queries:
+// - 'custom/bank/granted' lists the allowances granted by Address
+// - 'custom/bank/received' lists the allowances granted to Address
+type QueryAllowancesParams struct {
+	Address sdk.Address
+}
+
+func queryAllowances(
+	ctx sdk.Context,
+	route string,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	ak AllowanceKeeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryAllowancesParams
+
+	if errRes := cdc.UnmarshalBinary(req.Data, &params); errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.BANK_INVALID_PARAMS, errRes.Error()))
+	}
+
+	var allowances []Allowance
+	if route == QueryGranted {
+		allowances = ak.GetAllowancesByGranter(ctx, params.Address)
+	} else {
+		allowances = ak.GetAllowancesByGrantee(ctx, params.Address)
+	}
+
+	res, errRes := cdc.MarshalJSON(allowances)
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.BANK_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,97 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+)
+
+// SupplyKeeper tracks the total supply of each denom and the authorities
+// allowed to change it. Coins are minted by MsgLoad and withdrawn block
+// rewards, and burnt by MsgBurn and tx fees.
+type SupplyKeeper struct {
+	storeKey sdk.StoreKey
+}
+
+func NewSupplyKeeper(key sdk.StoreKey) SupplyKeeper {
+	return SupplyKeeper{storeKey: key}
+}
+
+// GetSupply - total supply of denom, zero if nothing was minted yet
+func (k SupplyKeeper) GetSupply(ctx sdk.Context, denom string) types.Coin {
+	supply := types.NewCoin(denom, 0)
+
+	err := utils.Retrieve(ctx.KVStore(k.storeKey), GetSupplyKey(denom), &supply)
+	if err != nil {
+		panic(err)
+	}
+
+	return supply
+}
+
+// GetTotalSupply - total supply of every allowed denom
+func (k SupplyKeeper) GetTotalSupply(ctx sdk.Context) types.Coins {
+	supply := types.Coins{}
+	for _, denom := range constants.ALL_DENOMS {
+		supply = append(supply, k.GetSupply(ctx, denom))
+	}
+	return supply
+}
+
+func (k SupplyKeeper) setSupply(ctx sdk.Context, supply types.Coin) {
+	if err := utils.Store(ctx.KVStore(k.storeKey), GetSupplyKey(supply.Denom), supply); err != nil {
+		panic(err)
+	}
+}
+
+// Mint - add amt to the supply of its denom
+func (k SupplyKeeper) Mint(ctx sdk.Context, amt types.Coin) {
+	k.setSupply(ctx, k.GetSupply(ctx, amt.Denom).Plus(amt))
+}
+
+// Burn - remove amt from the supply of its denom
+func (k SupplyKeeper) Burn(ctx sdk.Context, amt types.Coin) {
+	k.setSupply(ctx, k.GetSupply(ctx, amt.Denom).Minus(amt))
+}
+
+// InitSupply - set the supply at genesis to the coins of every account plus
+// held, the coins kept outside of accounts such as stakes
+func (k SupplyKeeper) InitSupply(ctx sdk.Context, am auth.AccountMapper, held types.Coins) {
+	total := sumAccounts(ctx, am).PlusMany(held)
+	for _, denom := range constants.ALL_DENOMS {
+		k.setSupply(ctx, types.NewCoinFromDec(denom, total.AmountOf(denom)))
+	}
+}
+
+// SupplyInvariant - compare the supply of every denom with the coins of every
+// account plus held, the coins kept outside of accounts such as stakes
+func SupplyInvariant(ctx sdk.Context, am auth.AccountMapper, k SupplyKeeper, held types.Coins) error {
+	total := sumAccounts(ctx, am).PlusMany(held)
+
+	for _, denom := range constants.ALL_DENOMS {
+		supply := k.GetSupply(ctx, denom)
+		if !supply.Amount.Equal(total.AmountOf(denom)) {
+			return fmt.Errorf(constants.BANK_SUPPLY_MISMATCH,
+				denom,
+				supply.Amount,
+				total.AmountOf(denom))
+		}
+	}
+
+	return nil
+}
+
+// sumAccounts - coins held by every account of the auth store
+func sumAccounts(ctx sdk.Context, am auth.AccountMapper) types.Coins {
+	total := types.NewDefaultCoins()
+	am.IterateAccounts(ctx, func(acc auth.BaseAccount) bool {
+		total = total.PlusMany(acc.GetCoins())
+		return false
+	})
+	return total
+}
@@ -0,0 +1,101 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank/messages"
+)
+
+// setAuthority - make addr a minter and burner without limits, the only admin
+func (in testInput) setAuthority(t *testing.T, addr sdk.Address) {
+	data := DefaultGenesisState()
+	data.Authorities = append(data.Authorities, types.NewAuthority(addr,
+		[]string{types.AUTHORITY_MINTER, types.AUTHORITY_BURNER}, nil, nil))
+	data.Admins = []sdk.Address{addr}
+	require.Nil(t, InitGenesis(in.ctx, in.sk, data))
+}
+
+func (in testInput) requireSupply(t *testing.T, denom string, amount int64) {
+	require.True(t, in.sk.GetSupply(in.ctx, denom).Equal(types.NewCoin(denom, amount)),
+		"%s supply is %s", denom, in.sk.GetSupply(in.ctx, denom))
+}
+
+func TestMintAndBurn(t *testing.T) {
+	in := setupBankTest(t)
+	in.requireSupply(t, constants.BOOKING_DENOM, 0)
+
+	in.sk.Mint(in.ctx, types.NewCoin(constants.BOOKING_DENOM, 30))
+	in.sk.Mint(in.ctx, types.NewCoin(constants.POS_DENOM, 5))
+	in.sk.Burn(in.ctx, types.NewCoin(constants.BOOKING_DENOM, 10))
+
+	in.requireSupply(t, constants.BOOKING_DENOM, 20)
+	in.requireSupply(t, constants.POS_DENOM, 5)
+	require.Len(t, in.sk.GetTotalSupply(in.ctx), len(constants.ALL_DENOMS))
+}
+
+func TestInitSupply(t *testing.T) {
+	in := setupBankTest(t)
+
+	// Stakes count towards the supply
+	held := types.Coins{types.NewCoin(constants.POS_DENOM, 7)}
+	in.sk.InitSupply(in.ctx, in.am, held)
+
+	in.requireSupply(t, constants.BOOKING_DENOM, 100)
+	in.requireSupply(t, constants.POS_DENOM, 57)
+	require.Nil(t, SupplyInvariant(in.ctx, in.am, in.sk, held))
+}
+
+func TestLoadAndBurnTrackSupply(t *testing.T) {
+	in := setupBankTest(t)
+	in.setAuthority(t, sender)
+	in.sk.InitSupply(in.ctx, in.am, nil)
+
+	load := messages.NewMsgLoad(alice, types.NewCoin(constants.BOOKING_DENOM, 30))
+
+	// Only minters load
+	res := in.handler(in.signedBy(alice), load)
+	require.False(t, res.IsOK())
+	in.requireSupply(t, constants.BOOKING_DENOM, 100)
+
+	res = in.handler(in.signedBy(sender), load)
+	require.True(t, res.IsOK(), res.Log)
+	in.requireSupply(t, constants.BOOKING_DENOM, 130)
+	require.Nil(t, SupplyInvariant(in.ctx, in.am, in.sk, nil))
+
+	// Only burners burn, from their own account
+	burn := messages.NewMsgBurn(sender, types.NewCoin(constants.BOOKING_DENOM, 20))
+	res = in.handler(in.signedBy(alice), burn)
+	require.False(t, res.IsOK())
+	in.requireSupply(t, constants.BOOKING_DENOM, 130)
+
+	res = in.handler(in.signedBy(sender), burn)
+	require.True(t, res.IsOK(), res.Log)
+	in.requireSupply(t, constants.BOOKING_DENOM, 110)
+	require.True(t, in.balance(sender, constants.BOOKING_DENOM).Equal(types.NewCoin(constants.BOOKING_DENOM, 80)))
+	require.Nil(t, SupplyInvariant(in.ctx, in.am, in.sk, nil))
+}
+
+func TestSupplyInvariant(t *testing.T) {
+	in := setupBankTest(t)
+	in.sk.InitSupply(in.ctx, in.am, nil)
+	require.Nil(t, SupplyInvariant(in.ctx, in.am, in.sk, nil))
+
+	// Coins created outside of the supply keeper break the invariant
+	acc := auth.NewSHRAccountWithAddress(alice)
+	acc.SetCoins(acc.Coins.Plus(types.NewCoin(constants.BOOKING_DENOM, 1)))
+	in.am.SetAccount(in.ctx, acc)
+	require.NotNil(t, SupplyInvariant(in.ctx, in.am, in.sk, nil))
+
+	in.sk.Mint(in.ctx, types.NewCoin(constants.BOOKING_DENOM, 1))
+	require.Nil(t, SupplyInvariant(in.ctx, in.am, in.sk, nil))
+
+	// Stakes are held outside of accounts
+	require.NotNil(t, SupplyInvariant(in.ctx, in.am, in.sk, types.Coins{types.NewCoin(constants.POS_DENOM, 1)}))
+}
@@ -7,8 +7,19 @@ var (
 	Amount         = "Amount"
 	Event          = "Event"
 	AccountAddress = "AccountAddress"
+	Action         = "Action"
+	ProposalID     = "ProposalID"
+	Spender        = "Spender"
+	Expiration     = "Expiration"
 
 	//Value -  []byte
 	Transfered = []byte("Transfered") //Transfer event fromAddress To Address
 	Credit     = []byte("Credit")     //event for credit
+
+	AuthorityProposed = []byte("AuthorityProposed") //admin proposed a change to the supply authorities
+	AuthorityApproved = []byte("AuthorityApproved") //admin approved an authority change
+	AuthorityChanged  = []byte("AuthorityChanged")  //authority change applied
+
+	AllowanceGranted = []byte("AllowanceGranted") //owner allowed a spender to send its coins
+	AllowanceRevoked = []byte("AllowanceRevoked") //owner removed the allowance of a spender
 )
@@ -0,0 +1,98 @@
+package booking
+
+import (
+	"strconv"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+// EndBlocker - activate bookings whose window started, expire or charge late
+// fees on overdue bookings, settle deposits whose claim or dispute window has
+// passed and disputes no arbiter ruled on in time.
+// Each queue is processed up to BOOKING_QUEUE_LIMIT entries per block.
+// Returns a tag for each booking state transition.
+func EndBlocker(ctx sdk.Context, k Keeper) (resTags sdk.Tags) {
+	now := ctx.BlockHeader().Time
+
+	for _, bookingID := range k.dequeueStarts(ctx, now) {
+		booking, err := k.Activate(ctx, bookingID)
+		if err != nil {
+			constants.LOGGER.Error("Booking activation failed",
+				"BookingID", bookingID,
+				"Error", err.Error(),
+			)
+			continue
+		}
+
+		resTags = resTags.
+			AppendTag(tags.Event, tags.BookingActivated).
+			AppendTag(tags.BookingId, []byte(booking.BookingID)).
+			AppendTag(tags.State, []byte(booking.State))
+	}
+
+	for _, bookingID := range k.dequeueExpiries(ctx, now) {
+		booking, lateFee, err := k.HandleOverdue(ctx, bookingID)
+		if err != nil {
+			constants.LOGGER.Error("Booking expiry failed",
+				"BookingID", bookingID,
+				"Error", err.Error(),
+			)
+			continue
+		}
+
+		switch {
+		case lateFee > 0:
+			resTags = resTags.
+				AppendTag(tags.Event, tags.LateFeeCharged).
+				AppendTag(tags.BookingId, []byte(booking.BookingID)).
+				AppendTag(tags.Amount, []byte(strconv.FormatInt(lateFee, 10)))
+		case booking.State == types.BOOKING_EXPIRED:
+			resTags = payoutTags(resTags.
+				AppendTag(tags.Event, tags.BookingExpired).
+				AppendTag(tags.BookingId, []byte(booking.BookingID)).
+				AppendTag(tags.State, []byte(booking.State)), booking.Payouts)
+		}
+	}
+
+	for _, bookingID := range k.dequeueDisputes(ctx, now) {
+		booking, err := k.ResolveDisputeByDefault(ctx, bookingID)
+		if err != nil {
+			constants.LOGGER.Error("Dispute default resolution failed",
+				"BookingID", bookingID,
+				"Error", err.Error(),
+			)
+			continue
+		}
+
+		constants.LOGGER.Info("Dispute timed out",
+			"BookingID", booking.BookingID,
+		)
+
+		resTags = resTags.
+			AppendTag(tags.Event, tags.DisputeResolved).
+			AppendTag(tags.BookingId, []byte(booking.BookingID)).
+			AppendTag(tags.State, []byte(booking.State))
+	}
+
+	for _, bookingID := range k.dequeueDeposits(ctx, now) {
+		booking, err := k.SettleDeposit(ctx, bookingID)
+		if err != nil {
+			constants.LOGGER.Error("Deposit settlement failed",
+				"BookingID", bookingID,
+				"Error", err.Error(),
+			)
+			continue
+		}
+
+		constants.LOGGER.Info("Deposit settled",
+			"BookingID", booking.BookingID,
+			"Deposit", booking.Deposit,
+		)
+	}
+
+	return resTags
+}
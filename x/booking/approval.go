@@ -0,0 +1,137 @@
+package booking
+
+import (
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	msg "github.com/sharering/shareledger/x/booking/messages"
+)
+
+// Approve - owner or operator confirms a requested booking before its start time
+func (k Keeper) Approve(ctx sdk.Context, msg msg.MsgApproveBooking) (types.Booking, error) {
+
+	booking, _, err := k.getRequest(ctx, msg.BookingID)
+	if err != nil {
+		return types.Booking{}, err
+	}
+
+	now := ctx.BlockHeader().Time
+	if now >= booking.StartTime {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_REQUEST_EXPIRED,
+			booking.BookingID,
+			booking.StartTime,
+			now)
+	}
+
+	k.dequeueExpiry(ctx, booking)
+
+	if err := booking.Transition(types.BOOKING_CONFIRMED); err != nil {
+		return types.Booking{}, err
+	}
+
+	k.enqueueStart(ctx, booking.StartTime, booking.BookingID)
+	k.enqueueExpiry(ctx, booking)
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// Reject - owner or operator declines a requested booking. Payment and deposit return to renter.
+func (k Keeper) Reject(ctx sdk.Context, msg msg.MsgRejectBooking) (types.Booking, error) {
+
+	booking, asset, err := k.getRequest(ctx, msg.BookingID)
+	if err != nil {
+		return types.Booking{}, err
+	}
+
+	k.dequeueExpiry(ctx, booking)
+
+	if err := booking.Transition(types.BOOKING_CANCELLED); err != nil {
+		return types.Booking{}, err
+	}
+
+	err = k.releaseFromEscrow(ctx, booking.BookingID, booking.Renter,
+		types.NewCoin(booking.GetDenom(), booking.Price+booking.Deposit))
+	if err != nil {
+		return types.Booking{}, err
+	}
+
+	booking.DepositSettled = true
+
+	// Free the reserved window
+	asset.Release(booking.BookingID)
+	asset.Status = len(asset.Calendar) == 0
+
+	if err := utils.Store(ctx.KVStore(k.assetKey), []byte(asset.UUID), asset); err != nil {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Asset",
+			constants.STORE_ASSET)
+	}
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// Activate - move a confirmed booking whose window started to active.
+// Bookings in any other state are returned unchanged.
+func (k Keeper) Activate(ctx sdk.Context, bookingID string) (types.Booking, error) {
+
+	booking, found := k.GetBooking(ctx, bookingID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_NOT_FOUND,
+			bookingID)
+	}
+
+	if booking.State != types.BOOKING_CONFIRMED {
+		return booking, nil
+	}
+
+	if err := booking.Transition(types.BOOKING_ACTIVE); err != nil {
+		return types.Booking{}, err
+	}
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// getRequest - retrieve a booking waiting for approval, checking signer owns or operates the asset
+func (k Keeper) getRequest(ctx sdk.Context, bookingID string) (types.Booking, types.Asset, error) {
+
+	booking, found := k.GetBooking(ctx, bookingID)
+	if !found {
+		return types.Booking{}, types.Asset{}, fmt.Errorf(constants.BOOKING_NOT_FOUND,
+			bookingID)
+	}
+
+	asset, err := k.getAsset(ctx, booking.UUID)
+	if err != nil {
+		return types.Booking{}, types.Asset{}, err
+	}
+
+	if !asset.CanManage(auth.GetSigner(ctx).GetAddress()) {
+		return types.Booking{}, types.Asset{}, fmt.Errorf(constants.BOOKING_APPROVE_UNAUTHORIZED,
+			utils.ByteToString(asset.Creator),
+			asset.UUID)
+	}
+
+	if booking.State != types.BOOKING_REQUESTED {
+		return types.Booking{}, types.Asset{}, fmt.Errorf(constants.BOOKING_NOT_REQUESTED,
+			booking.BookingID)
+	}
+
+	return booking, asset, nil
+}
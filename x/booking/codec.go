@@ -7,5 +7,13 @@ import (
 func RegisterCodec(cdc *wire.Codec) *wire.Codec {
 	cdc.RegisterConcrete(msg.MsgBook{}, "shareledger/booking/MsgBook", nil)
 	cdc.RegisterConcrete(msg.MsgComplete{}, "shareledger/booking/MsgComplete", nil)
+	cdc.RegisterConcrete(msg.MsgCancelBooking{}, "shareledger/booking/MsgCancelBooking", nil)
+	cdc.RegisterConcrete(msg.MsgClaimDamage{}, "shareledger/booking/MsgClaimDamage", nil)
+	cdc.RegisterConcrete(msg.MsgOpenDispute{}, "shareledger/booking/MsgOpenDispute", nil)
+	cdc.RegisterConcrete(msg.MsgResolveDispute{}, "shareledger/booking/MsgResolveDispute", nil)
+	cdc.RegisterConcrete(msg.MsgApproveBooking{}, "shareledger/booking/MsgApproveBooking", nil)
+	cdc.RegisterConcrete(msg.MsgRejectBooking{}, "shareledger/booking/MsgRejectBooking", nil)
+	cdc.RegisterConcrete(msg.MsgExtendBooking{}, "shareledger/booking/MsgExtendBooking", nil)
+	cdc.RegisterConcrete(msg.MsgRate{}, "shareledger/booking/MsgRate", nil)
 	return cdc
 }
\ No newline at end of file
@@ -0,0 +1,223 @@
+package booking
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	msg "github.com/sharering/shareledger/x/booking/messages"
+)
+
+// OpenDispute - freeze the escrowed funds of a booking until an arbiter rules
+// or the dispute times out. Renter and owner can open a dispute. The arbiter of
+// the asset at that time rules on it, the asset keeps its arbiter until then.
+func (k Keeper) OpenDispute(ctx sdk.Context, msg msg.MsgOpenDispute) (types.Booking, error) {
+
+	booking, found := k.GetBooking(ctx, msg.BookingID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_NOT_FOUND,
+			msg.BookingID)
+	}
+
+	asset, err := k.getAsset(ctx, booking.UUID)
+	if err != nil {
+		return types.Booking{}, err
+	}
+
+	signer := auth.GetSigner(ctx).GetAddress()
+
+	if !bytes.Equal(signer, booking.Renter) && !bytes.Equal(signer, asset.Creator) {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_DISPUTE_UNAUTHORIZED,
+			utils.ByteToString(signer),
+			booking.BookingID)
+	}
+
+	if booking.Dispute != nil {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_DISPUTE_EXISTS,
+			booking.BookingID)
+	}
+
+	if !types.CanTransition(booking.State, types.BOOKING_DISPUTED) {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_INVALID_TRANSITION,
+			booking.BookingID,
+			booking.State,
+			types.BOOKING_DISPUTED)
+	}
+
+	if booking.GetEscrowed() == 0 {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_NOTHING_TO_DISPUTE,
+			booking.BookingID)
+	}
+
+	now := ctx.BlockHeader().Time
+
+	dispute := types.NewDispute(signer, msg.Reason, now, booking.State, asset.Arbiter)
+	booking.Dispute = &dispute
+	booking.State = types.BOOKING_DISPUTED
+
+	k.enqueueDispute(ctx, now+constants.BOOKING_DISPUTE_TIMEOUT, booking.BookingID)
+
+	asset.OpenDisputes++
+	if err := utils.Store(ctx.KVStore(k.assetKey), []byte(asset.UUID), asset); err != nil {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Asset",
+			constants.STORE_ASSET)
+	}
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// ResolveDispute - apply the ruling of an arbiter of the asset. Returns the amount paid to owner.
+func (k Keeper) ResolveDispute(ctx sdk.Context, msg msg.MsgResolveDispute) (types.Booking, int64, error) {
+
+	booking, found := k.GetBooking(ctx, msg.BookingID)
+	if !found {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_NOT_FOUND,
+			msg.BookingID)
+	}
+
+	if !booking.HasOpenDispute() {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_DISPUTE_NOT_OPEN,
+			booking.BookingID)
+	}
+
+	asset, err := k.getAsset(ctx, booking.UUID)
+	if err != nil {
+		return types.Booking{}, 0, err
+	}
+
+	arbiter := auth.GetSigner(ctx).GetAddress()
+
+	if !k.IsArbiter(ctx, booking, asset, arbiter) {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_NOT_ARBITER,
+			utils.ByteToString(arbiter),
+			asset.UUID)
+	}
+
+	escrowed := booking.GetEscrowed()
+	if msg.RenterAmount < 0 || msg.RenterAmount > escrowed {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_INVALID_RULING,
+			msg.RenterAmount,
+			escrowed)
+	}
+
+	k.dequeueDispute(ctx, booking.Dispute.OpenedAt+constants.BOOKING_DISPUTE_TIMEOUT, booking.BookingID)
+
+	booking, err = k.settleDispute(ctx, booking, asset, arbiter, msg.RenterAmount)
+	if err != nil {
+		return types.Booking{}, 0, err
+	}
+
+	return booking, escrowed - msg.RenterAmount, nil
+}
+
+// ResolveDisputeByDefault - settle a dispute nobody ruled on in time. Funds
+// go where they would have gone without the dispute: the price to the owner,
+// the claimed damages to the owner and the rest of the deposit to the renter.
+func (k Keeper) ResolveDisputeByDefault(ctx sdk.Context, bookingID string) (types.Booking, error) {
+
+	booking, found := k.GetBooking(ctx, bookingID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_NOT_FOUND,
+			bookingID)
+	}
+
+	if !booking.HasOpenDispute() {
+		return booking, nil
+	}
+
+	asset, err := k.getAsset(ctx, booking.UUID)
+	if err != nil {
+		return types.Booking{}, err
+	}
+
+	var ownerAmount int64
+	if booking.IsOpen() {
+		ownerAmount += booking.Price
+	}
+	if !booking.DepositSettled && booking.Claim != nil {
+		ownerAmount += booking.Claim.Amount
+	}
+
+	return k.settleDispute(ctx, booking, asset, nil, booking.GetEscrowed()-ownerAmount)
+}
+
+// IsArbiter - whether address can resolve the dispute of booking: the arbiter
+// of the asset when the dispute was opened or a global arbiter, unless it is
+// the renter, the owner or an operator of the asset
+func (k Keeper) IsArbiter(ctx sdk.Context, booking types.Booking, asset types.Asset, address sdk.Address) bool {
+	if bytes.Equal(booking.Renter, address) || asset.CanManage(address) {
+		return false
+	}
+	if len(booking.Dispute.Arbiter) > 0 && bytes.Equal(booking.Dispute.Arbiter, address) {
+		return true
+	}
+	return k.supplyKeeper.IsArbiter(ctx, address)
+}
+
+// settleDispute - pay out escrowed funds and close the booking
+func (k Keeper) settleDispute(
+	ctx sdk.Context,
+	booking types.Booking,
+	asset types.Asset,
+	arbiter sdk.Address,
+	renterAmount int64,
+) (types.Booking, error) {
+
+	ownerAmount := booking.GetEscrowed() - renterAmount
+
+	err := k.releaseFromEscrow(ctx, booking.BookingID, booking.Renter,
+		types.NewCoin(booking.GetDenom(), renterAmount))
+	if err != nil {
+		return types.Booking{}, err
+	}
+
+	// The owner side of the ruling is revenue of the asset like any other
+	if err := k.settle(ctx, &booking, asset, ownerAmount); err != nil {
+		return types.Booking{}, err
+	}
+
+	now := ctx.BlockHeader().Time
+
+	// A booking disputed before completion is closed by the ruling
+	if booking.IsOpen() {
+		booking.CompletedAt = now
+
+		asset.Release(booking.BookingID)
+		asset.Status = len(asset.Calendar) == 0
+	}
+
+	if asset.OpenDisputes > 0 {
+		asset.OpenDisputes--
+	}
+
+	if err := utils.Store(ctx.KVStore(k.assetKey), []byte(asset.UUID), asset); err != nil {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Asset",
+			constants.STORE_ASSET)
+	}
+
+	if err := booking.Transition(types.BOOKING_COMPLETED); err != nil {
+		return types.Booking{}, err
+	}
+
+	booking.DepositSettled = true
+	booking.Dispute.Resolved = true
+	booking.Dispute.ResolvedBy = arbiter
+	booking.Dispute.RenterAmount = renterAmount
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, err
+	}
+
+	return booking, nil
+}
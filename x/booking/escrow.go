@@ -0,0 +1,62 @@
+package booking
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// EscrowBalance - funds held on behalf of a booking
+type EscrowBalance struct {
+	BookingID string      `json:"bookingId"`
+	Address   sdk.Address `json:"address"`
+	Coins     types.Coins `json:"coins"`
+}
+
+// GetEscrowAddress - address of the module account holding funds of a booking.
+// It is derived from the booking ID so that no private key controls it.
+func GetEscrowAddress(bookingID string) sdk.Address {
+	hash := sha256.Sum256([]byte(constants.PREFIX_BOOKING_ESCROW + bookingID))
+	return sdk.Address(hash[:20])
+}
+
+// GetEscrowBalance - coins currently held in escrow for a booking
+func (k Keeper) GetEscrowBalance(ctx sdk.Context, bookingID string) EscrowBalance {
+	address := GetEscrowAddress(bookingID)
+	return EscrowBalance{
+		BookingID: bookingID,
+		Address:   address,
+		Coins:     k.bankKeeper.GetCoins(ctx, address),
+	}
+}
+
+// lockInEscrow - move amt from an account to the escrow of a booking
+func (k Keeper) lockInEscrow(ctx sdk.Context, bookingID string, from sdk.Address, amt types.Coin) error {
+	return k.transfer(ctx, from, GetEscrowAddress(bookingID), amt)
+}
+
+// releaseFromEscrow - move amt from the escrow of a booking to an account
+func (k Keeper) releaseFromEscrow(ctx sdk.Context, bookingID string, to sdk.Address, amt types.Coin) error {
+	return k.transfer(ctx, GetEscrowAddress(bookingID), to, amt)
+}
+
+// transfer - move coins between two accounts. Total supply is unchanged.
+func (k Keeper) transfer(ctx sdk.Context, from sdk.Address, to sdk.Address, amt types.Coin) error {
+	if amt.IsZero() {
+		return nil
+	}
+
+	if _, err := k.bankKeeper.SubtractCoin(ctx, from, amt); err != nil {
+		return fmt.Errorf(constants.BOOKING_INSUFFICIENT_BALANCE, from)
+	}
+
+	if _, err := k.bankKeeper.AddCoin(ctx, to, amt); err != nil {
+		return fmt.Errorf(err.Error())
+	}
+
+	return nil
+}
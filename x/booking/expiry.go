@@ -0,0 +1,136 @@
+package booking
+
+import (
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+)
+
+// GetExpiryTime - when a booking is next checked for expiry. Requests expire at
+// start time, other bookings once the grace period after the last charged
+// late period is over.
+func GetExpiryTime(booking types.Booking) int64 {
+	if booking.State == types.BOOKING_REQUESTED {
+		return booking.StartTime
+	}
+	return booking.EndTime + constants.BOOKING_GRACE_PERIOD + booking.LatePeriods*constants.BOOKING_TIME_UNIT
+}
+
+// HandleOverdue - expire a request not approved in time, or an overdue booking.
+// If the asset charges a late fee, the renter pays it for one more period instead,
+// until BOOKING_MAX_LATE_PERIODS or the renter runs out of funds. Late fees are
+// settled to the owners of the asset like the booking price.
+// Returns the late fee charged.
+func (k Keeper) HandleOverdue(ctx sdk.Context, bookingID string) (types.Booking, int64, error) {
+
+	booking, found := k.GetBooking(ctx, bookingID)
+	if !found {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_NOT_FOUND,
+			bookingID)
+	}
+
+	asset, err := k.getAsset(ctx, booking.UUID)
+	if err != nil {
+		return types.Booking{}, 0, err
+	}
+
+	switch booking.State {
+	case types.BOOKING_REQUESTED:
+		booking, err = k.expire(ctx, booking, asset)
+		return booking, 0, err
+
+	case types.BOOKING_CONFIRMED:
+		// Start queue is processed first, this only happens for a full queue
+		k.dequeueStart(ctx, booking.StartTime, booking.BookingID)
+		booking.State = types.BOOKING_ACTIVE
+
+	case types.BOOKING_ACTIVE:
+
+	default:
+		// Completed, cancelled or disputed meanwhile
+		return booking, 0, nil
+	}
+
+	if asset.LateFee > 0 && booking.LatePeriods < constants.BOOKING_MAX_LATE_PERIODS {
+		err = k.lockInEscrow(ctx, booking.BookingID, booking.Renter,
+			types.NewCoin(booking.GetDenom(), asset.LateFee))
+
+		if err == nil {
+			if err := k.settle(ctx, &booking, asset, asset.LateFee); err != nil {
+				return types.Booking{}, 0, err
+			}
+
+			booking.LatePeriods++
+			booking.LateFees += asset.LateFee
+
+			k.enqueueExpiry(ctx, booking)
+
+			if err := k.setBooking(ctx, booking); err != nil {
+				return types.Booking{}, 0, err
+			}
+
+			return booking, asset.LateFee, nil
+		}
+
+		constants.LOGGER.Info("Renter cannot pay late fee",
+			"BookingID", booking.BookingID,
+			"Error", err.Error(),
+		)
+	}
+
+	booking, err = k.expire(ctx, booking, asset)
+	return booking, 0, err
+}
+
+// expire - close a booking. A request is refunded to the renter together with
+// its deposit. An overdue booking is settled to the owner and keeps its deposit
+// in escrow for the claim window, as if it was completed.
+func (k Keeper) expire(ctx sdk.Context, booking types.Booking, asset types.Asset) (types.Booking, error) {
+
+	requested := booking.State == types.BOOKING_REQUESTED
+
+	if err := booking.Transition(types.BOOKING_EXPIRED); err != nil {
+		return types.Booking{}, err
+	}
+
+	now := ctx.BlockHeader().Time
+	booking.CompletedAt = now
+
+	if requested {
+		err := k.releaseFromEscrow(ctx, booking.BookingID, booking.Renter,
+			types.NewCoin(booking.GetDenom(), booking.Price+booking.Deposit))
+		if err != nil {
+			return types.Booking{}, err
+		}
+		booking.DepositSettled = true
+	} else {
+		if err := k.settle(ctx, &booking, asset, booking.Price); err != nil {
+			return types.Booking{}, err
+		}
+
+		if booking.Deposit > 0 {
+			k.enqueueDeposit(ctx, now+constants.BOOKING_CLAIM_WINDOW, booking.BookingID)
+		} else {
+			booking.DepositSettled = true
+		}
+	}
+
+	asset.Release(booking.BookingID)
+	asset.Status = len(asset.Calendar) == 0
+
+	if err := utils.Store(ctx.KVStore(k.assetKey), []byte(asset.UUID), asset); err != nil {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Asset",
+			constants.STORE_ASSET)
+	}
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, err
+	}
+
+	return booking, nil
+}
@@ -0,0 +1,103 @@
+package booking
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	msg "github.com/sharering/shareledger/x/booking/messages"
+)
+
+// Extend - renter keeps the asset for more pricing units. The extra window must be
+// free in the asset calendar and the whole booking within the duration limits.
+// Only the extra units are charged and added to the escrow. Returns the amount charged.
+func (k Keeper) Extend(ctx sdk.Context, msg msg.MsgExtendBooking) (types.Booking, int64, error) {
+
+	booking, found := k.GetBooking(ctx, msg.BookingID)
+	if !found {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_NOT_FOUND,
+			msg.BookingID)
+	}
+
+	signer := auth.GetSigner(ctx).GetAddress()
+	if !bytes.Equal(signer, booking.Renter) {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_EXTEND_UNAUTHORIZED,
+			utils.ByteToString(booking.Renter),
+			booking.BookingID)
+	}
+
+	if booking.State != types.BOOKING_CONFIRMED && booking.State != types.BOOKING_ACTIVE {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_NOT_EXTENDABLE,
+			booking.BookingID,
+			booking.State)
+	}
+
+	now := ctx.BlockHeader().Time
+	if now >= booking.EndTime {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_ALREADY_ENDED,
+			booking.BookingID,
+			booking.EndTime,
+			now)
+	}
+
+	asset, err := k.getAsset(ctx, booking.UUID)
+	if err != nil {
+		return types.Booking{}, 0, err
+	}
+
+	// Extensions are priced with the model agreed when booking
+	pricing := booking.GetTerms(asset).Pricing
+	end := booking.EndTime + msg.Periods*pricing.Unit
+
+	if r, found := asset.FindConflict(booking.EndTime, end); found {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_OVERLAP,
+			asset.UUID,
+			r.StartTime,
+			r.EndTime,
+			r.BookingID)
+	}
+
+	// Duration limits apply to the extended booking as a whole
+	if _, err := GetBookingPrice(pricing, booking.StartTime, end); err != nil {
+		return types.Booking{}, 0, err
+	}
+
+	charged, err := pricing.GetPrice(pricing.GetUnits(booking.EndTime, end))
+	if err != nil {
+		return types.Booking{}, 0, err
+	}
+
+	err = k.lockInEscrow(ctx, booking.BookingID, booking.Renter,
+		types.NewCoin(booking.GetDenom(), charged))
+	if err != nil {
+		return types.Booking{}, 0, err
+	}
+
+	// Overdue check moves with the end time
+	k.dequeueExpiry(ctx, booking)
+
+	booking.EndTime = end
+	booking.Price += charged
+
+	k.enqueueExpiry(ctx, booking)
+
+	asset.Release(booking.BookingID)
+	asset.Reserve(types.NewReservation(booking.BookingID, booking.Renter, booking.StartTime, booking.EndTime))
+
+	if err := utils.Store(ctx.KVStore(k.assetKey), []byte(asset.UUID), asset); err != nil {
+		return types.Booking{}, 0, fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Asset",
+			constants.STORE_ASSET)
+	}
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, 0, err
+	}
+
+	return booking, charged, nil
+}
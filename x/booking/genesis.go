@@ -0,0 +1,31 @@
+package booking
+
+import (
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+)
+
+// GenesisState - booking settings provided at genesis
+type GenesisState struct {
+	Params Params `json:"params"`
+}
+
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params: DefaultParams(),
+	}
+}
+
+// InitGenesis - store genesis params. Genesis files without a booking
+// section keep the default params.
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) error {
+	if data.Params.Commission.IsNil() && data.Params.ReferralShare.IsNil() {
+		data.Params = DefaultParams()
+	}
+
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+
+	k.SetParams(ctx, data.Params)
+	return nil
+}
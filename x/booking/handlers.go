@@ -3,12 +3,15 @@ package booking
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 
 	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
 
 	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
 	"github.com/sharering/shareledger/utils"
 	"github.com/sharering/shareledger/x/booking/messages"
+	tags "github.com/sharering/shareledger/x/booking/tags"
 )
 
 func NewHandler(k Keeper) sdk.Handler {
@@ -25,6 +28,22 @@ func NewHandler(k Keeper) sdk.Handler {
 			return handleBooking(ctx, k, msg)
 		case messages.MsgComplete:
 			return handleComplete(ctx, k, msg)
+		case messages.MsgCancelBooking:
+			return handleCancel(ctx, k, msg)
+		case messages.MsgClaimDamage:
+			return handleClaimDamage(ctx, k, msg)
+		case messages.MsgOpenDispute:
+			return handleOpenDispute(ctx, k, msg)
+		case messages.MsgResolveDispute:
+			return handleResolveDispute(ctx, k, msg)
+		case messages.MsgApproveBooking:
+			return handleApprove(ctx, k, msg)
+		case messages.MsgRejectBooking:
+			return handleReject(ctx, k, msg)
+		case messages.MsgExtendBooking:
+			return handleExtend(ctx, k, msg)
+		case messages.MsgRate:
+			return handleRate(ctx, k, msg)
 
 		default:
 			errMsg := fmt.Sprintf("Unrecognized trace Msg type: %v", reflect.TypeOf(msg).Name())
@@ -44,8 +63,10 @@ func handleBooking(ctx sdk.Context, k Keeper, msg messages.MsgBook) sdk.Result {
 	fee, denom := utils.GetMsgFee(msg)
 
 	return sdk.Result{
-		Log:       fmt.Sprintf("%s", booking.String()),
-		Tags:      msg.Tags(),
+		Log: fmt.Sprintf("%s", booking.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.BookingId, []byte(booking.BookingID)).
+			AppendTag(tags.State, []byte(booking.State)),
 		FeeAmount: fee,
 		FeeDenom:  denom,
 	}
@@ -53,7 +74,7 @@ func handleBooking(ctx sdk.Context, k Keeper, msg messages.MsgBook) sdk.Result {
 
 func handleComplete(ctx sdk.Context, k Keeper, msg messages.MsgComplete) sdk.Result {
 
-	booking, err := k.Complete(ctx, msg)
+	booking, refund, err := k.Complete(ctx, msg)
 
 	if err != nil {
 		return sdk.ErrInternal(err.Error()).Result()
@@ -61,10 +82,180 @@ func handleComplete(ctx sdk.Context, k Keeper, msg messages.MsgComplete) sdk.Res
 
 	fee, denom := utils.GetMsgFee(msg)
 
+	resTags := msg.Tags()
+	if booking.CompletedAt < booking.EndTime {
+		resTags = sdk.NewTags(tags.Event, tags.ReturnedEarly).
+			AppendTag(tags.BookingId, []byte(booking.BookingID)).
+			AppendTag(tags.Refund, []byte(strconv.FormatInt(refund, 10)))
+	}
+
 	return sdk.Result{
 		Log:       fmt.Sprintf("Completed %s", booking.String()),
+		Tags:      payoutTags(resTags.AppendTag(tags.State, []byte(booking.State)), booking.Payouts),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+func handleCancel(ctx sdk.Context, k Keeper, msg messages.MsgCancelBooking) sdk.Result {
+
+	booking, refund, err := k.Cancel(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log: fmt.Sprintf("Cancelled %s", booking.String()),
+		Tags: payoutTags(msg.Tags().
+			AppendTag(tags.Refund, []byte(strconv.FormatInt(refund, 10))).
+			AppendTag(tags.Payout, []byte(strconv.FormatInt(booking.Price-refund, 10))).
+			AppendTag(tags.State, []byte(booking.State)), booking.Payouts),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+func handleClaimDamage(ctx sdk.Context, k Keeper, msg messages.MsgClaimDamage) sdk.Result {
+
+	booking, err := k.ClaimDamage(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log:       fmt.Sprintf("Claimed %s", booking.String()),
 		Tags:      msg.Tags(),
 		FeeAmount: fee,
 		FeeDenom:  denom,
 	}
 }
+
+func handleOpenDispute(ctx sdk.Context, k Keeper, msg messages.MsgOpenDispute) sdk.Result {
+
+	booking, err := k.OpenDispute(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log:       fmt.Sprintf("Disputed %s", booking.String()),
+		Tags:      msg.Tags().AppendTag(tags.State, []byte(booking.State)),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+func handleResolveDispute(ctx sdk.Context, k Keeper, msg messages.MsgResolveDispute) sdk.Result {
+
+	booking, ownerAmount, err := k.ResolveDispute(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log: fmt.Sprintf("Resolved %s", booking.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.Payout, []byte(strconv.FormatInt(ownerAmount, 10))).
+			AppendTag(tags.State, []byte(booking.State)),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+func handleApprove(ctx sdk.Context, k Keeper, msg messages.MsgApproveBooking) sdk.Result {
+
+	booking, err := k.Approve(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log:       fmt.Sprintf("Approved %s", booking.String()),
+		Tags:      msg.Tags().AppendTag(tags.State, []byte(booking.State)),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+func handleReject(ctx sdk.Context, k Keeper, msg messages.MsgRejectBooking) sdk.Result {
+
+	booking, err := k.Reject(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log: fmt.Sprintf("Rejected %s", booking.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.Refund, []byte(strconv.FormatInt(booking.Price+booking.Deposit, 10))).
+			AppendTag(tags.State, []byte(booking.State)),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+func handleExtend(ctx sdk.Context, k Keeper, msg messages.MsgExtendBooking) sdk.Result {
+
+	booking, charged, err := k.Extend(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log: fmt.Sprintf("Extended %s", booking.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.Amount, []byte(strconv.FormatInt(charged, 10))).
+			AppendTag(tags.EndTime, []byte(strconv.FormatInt(booking.EndTime, 10))),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+func handleRate(ctx sdk.Context, k Keeper, msg messages.MsgRate) sdk.Result {
+
+	booking, rated, err := k.Rate(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	fee, denom := utils.GetMsgFee(msg)
+
+	return sdk.Result{
+		Log: fmt.Sprintf("Rated %s", booking.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.Rated, []byte(utils.ByteToString(rated))),
+		FeeAmount: fee,
+		FeeDenom:  denom,
+	}
+}
+
+// payoutTags - one Recipient tag per settlement payout, as role:address:amount
+func payoutTags(resTags sdk.Tags, payouts []types.Payout) sdk.Tags {
+	for _, p := range payouts {
+		resTags = resTags.AppendTag(tags.Recipient,
+			[]byte(fmt.Sprintf("%s:%s:%s", p.Role, utils.ByteToString(p.Recipient), p.Amount)))
+	}
+	return resTags
+}
@@ -0,0 +1,36 @@
+package booking
+
+import (
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+)
+
+// GetBookingsByRenter - one page of the bookings made by renter
+func (k Keeper) GetBookingsByRenter(ctx sdk.Context, renter sdk.Address, page int, limit int) []types.Booking {
+	return k.getIndexedBookings(ctx, GetRenterIndexPrefix(renter), page, limit)
+}
+
+// GetBookingsByAsset - one page of the bookings made on an asset
+func (k Keeper) GetBookingsByAsset(ctx sdk.Context, uuid string, page int, limit int) []types.Booking {
+	return k.getIndexedBookings(ctx, GetAssetIndexPrefix(uuid), page, limit)
+}
+
+// GetActiveBookings - one page of the bookings currently in the active state
+func (k Keeper) GetActiveBookings(ctx sdk.Context, page int, limit int) []types.Booking {
+	return k.getIndexedBookings(ctx, ActiveIndexKey, page, limit)
+}
+
+// getIndexedBookings - resolve the booking IDs stored under an index prefix
+func (k Keeper) getIndexedBookings(ctx sdk.Context, prefix []byte, page int, limit int) []types.Booking {
+	bookings := []types.Booking{}
+
+	for _, id := range utils.GetPageValues(ctx.KVStore(k.bookingKey), prefix, page, limit) {
+		if booking, found := k.GetBooking(ctx, string(id)); found {
+			bookings = append(bookings, booking)
+		}
+	}
+
+	return bookings
+}
@@ -2,6 +2,7 @@ package booking
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 
 	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
@@ -12,6 +13,7 @@ import (
 	"github.com/sharering/shareledger/types"
 	utils "github.com/sharering/shareledger/utils"
 	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank"
 	msg "github.com/sharering/shareledger/x/booking/messages"
 )
 
@@ -20,14 +22,18 @@ type Keeper struct {
 	assetKey   sdk.StoreKey // asset key
 	//accountKey sdk.StoreKey // account key
 	accountMapper auth.AccountMapper // account mapper
+	bankKeeper    bank.Keeper        // moves funds in and out of booking escrows
+	supplyKeeper  bank.SupplyKeeper  // authorities, among them the global arbiters
 	cdc           *wire.Codec
 }
 
-func NewKeeper(bookingKey sdk.StoreKey, assetKey sdk.StoreKey, am auth.AccountMapper, cdc *wire.Codec) Keeper {
+func NewKeeper(bookingKey sdk.StoreKey, assetKey sdk.StoreKey, am auth.AccountMapper, supplyKeeper bank.SupplyKeeper, cdc *wire.Codec) Keeper {
 	return Keeper{
 		bookingKey:    bookingKey,
 		assetKey:      assetKey,
 		accountMapper: am,
+		bankKeeper:    bank.NewKeeper(am),
+		supplyKeeper:  supplyKeeper,
 		//accountKey: accountKey,
 		cdc: cdc,
 	}
@@ -40,17 +46,19 @@ func (k Keeper) Book(ctx sdk.Context, msg msg.MsgBook) (types.Booking, error) {
 	bookingStore := ctx.KVStore(k.bookingKey)
 	assetStore := ctx.KVStore(k.assetKey)
 
-	bookingId, err := utils.GenUUID(msg)
-
-	if err != nil {
-		return types.Booking{}, fmt.Errorf("bookingID generation failed %s", err.Error())
+	// Bookings can only be made for the future
+	now := ctx.BlockHeader().Time
+	if msg.StartTime < now {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_START_IN_PAST,
+			msg.StartTime,
+			now)
 	}
 
 	// Checking asset
 
 	var asset types.Asset
 
-	err = utils.Retrieve(assetStore, []byte(msg.UUID), &asset)
+	err := utils.Retrieve(assetStore, []byte(msg.UUID), &asset)
 
 	if err != nil {
 		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_RETRIEVAL,
@@ -64,14 +72,31 @@ func (k Keeper) Book(ctx sdk.Context, msg msg.MsgBook) (types.Booking, error) {
 			constants.STORE_BOOKING)
 	}
 
-	if asset.Status == false {
-		return types.Booking{}, fmt.Errorf(constants.BOOKING_ASSET_RENTED,
+	// Asset is marked unavailable without any reservation
+	if asset.Status == false && len(asset.Calendar) == 0 {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_ASSET_UNAVAILABLE,
 			asset.UUID)
 	}
 
+	// Requested window must not intersect any existing reservation
+	if r, found := asset.FindConflict(msg.StartTime, msg.EndTime); found {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_OVERLAP,
+			asset.UUID,
+			r.StartTime,
+			r.EndTime,
+			r.BookingID)
+	}
+
 	// For a booking, renter is the account signing this message
 	renter := auth.GetSigner(ctx)
 
+	// Disputes of the booking would be ruled by the renter
+	if len(asset.Arbiter) > 0 && bytes.Equal(asset.Arbiter, renter.GetAddress()) {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_RENTER_IS_ARBITER,
+			utils.ByteToString(renter.GetAddress()),
+			asset.UUID)
+	}
+
 	// renter account
 	renterAcc := k.accountMapper.GetAccount(ctx, renter.GetAddress())
 
@@ -81,37 +106,78 @@ func (k Keeper) Book(ctx sdk.Context, msg msg.MsgBook) (types.Booking, error) {
 			constants.STORE_BANK)
 	}
 
-	// Calculate fee and deduce from Renter account
-	value := msg.Duration * asset.Fee
-
-	renterCoins := renterAcc.GetCoins()
+	sequence := k.getSequence(ctx)
 
-	// fmt.Printf("RENTER COINS: %v\n", renterCoins)
+	bookingId, err := GetBookingID(ctx.BlockHeight(),
+		sequence,
+		renter.GetAddress(),
+		renterAcc.GetNonce(),
+		msg)
+	if err != nil {
+		return types.Booking{}, fmt.Errorf("bookingID generation failed %s", err.Error())
+	}
 
-	renterCoinsAfter := renterCoins.Minus(types.NewCoin(constants.BOOKING_DENOM, value))
+	// Never overwrite another booking
+	if bookingStore.Has([]byte(bookingId)) {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_ID_EXISTS,
+			bookingId)
+	}
 
-	// fmt.Printf("RENTER COINS AFTER: %v\n", renterCoinsAfter)
+	// Calculate fee to be held in escrow until completion. The booking keeps
+	// the terms of the asset it was priced with
+	terms := types.NewBookingTerms(asset)
+	value, err := GetBookingPrice(terms.Pricing, msg.StartTime, msg.EndTime)
+	if err != nil {
+		return types.Booking{}, err
+	}
 
-	if !renterCoinsAfter.IsNotNegative() {
-		return types.Booking{}, fmt.Errorf(constants.BOOKING_INSUFFICIENT_BALANCE,
-			renter.GetAddress())
+	// Assets requiring approval hold the window until the owner decides
+	state := types.BOOKING_CONFIRMED
+	if asset.ApprovalRequired {
+		state = types.BOOKING_REQUESTED
 	}
 
 	booking := types.NewBooking(bookingId,
 		renter.GetAddress(),
 		msg.UUID,
-		msg.Duration,
-		false)
+		msg.StartTime,
+		msg.EndTime,
+		value,
+		state)
+	booking.Deposit = asset.Deposit
+	booking.Denom = terms.Pricing.Denom
+	booking.Terms = &terms
+
+	if len(msg.Referrer) > 0 {
+		if bytes.Equal(msg.Referrer, renter.GetAddress()) {
+			return types.Booking{}, fmt.Errorf(constants.BOOKING_INVALID_REFERRER)
+		}
+		booking.Referrer = msg.Referrer
+	}
 
-	// Update asset status
+	// Reserve the window. Asset stays rented while it has reservations
+	asset.Reserve(types.NewReservation(booking.BookingID, booking.Renter, booking.StartTime, booking.EndTime))
 	asset.Status = false
 
-	err = utils.Store(bookingStore, []byte(booking.BookingID), booking)
+	// Move payment and deposit from renter to the escrow of this booking
+	err = k.lockInEscrow(ctx, booking.BookingID, renter.GetAddress(),
+		types.NewCoin(booking.GetDenom(), value+booking.Deposit))
 	if err != nil {
-		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
-			"types.Booking",
-			constants.STORE_BOOKING)
+		return types.Booking{}, err
+	}
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, err
+	}
+	bookingStore.Set(GetRenterIndexKey(booking.Renter, booking.BookingID), []byte(booking.BookingID))
+	bookingStore.Set(GetAssetIndexKey(booking.UUID, booking.BookingID), []byte(booking.BookingID))
+
+	k.setSequence(ctx, sequence+1)
+
+	if booking.State == types.BOOKING_CONFIRMED {
+		k.enqueueStart(ctx, booking.StartTime, booking.BookingID)
 	}
+	k.enqueueExpiry(ctx, booking)
 
 	err = utils.Store(assetStore, []byte(asset.UUID), asset)
 	if err != nil {
@@ -120,17 +186,13 @@ func (k Keeper) Book(ctx sdk.Context, msg msg.MsgBook) (types.Booking, error) {
 			constants.STORE_ASSET)
 	}
 
-	// Save new balance
-	renterAcc.SetCoins(renterCoinsAfter)
-
-	// save new account
-	k.accountMapper.SetAccount(ctx, renterAcc)
-
 	return booking, nil
 
 }
 
-func (k Keeper) Complete(ctx sdk.Context, msg msg.MsgComplete) (types.Booking, error) {
+// Complete - renter returns the asset. The price goes to the owner, minus the
+// early return refund when returned before the end time.
+func (k Keeper) Complete(ctx sdk.Context, msg msg.MsgComplete) (types.Booking, int64, error) {
 
 	bookingStore := ctx.KVStore(k.bookingKey)
 	assetStore := ctx.KVStore(k.assetKey)
@@ -141,86 +203,516 @@ func (k Keeper) Complete(ctx sdk.Context, msg msg.MsgComplete) (types.Booking, e
 
 	err := utils.Retrieve(bookingStore, []byte(msg.BookingID), &booking)
 	if err != nil {
-		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_RETRIEVAL,
+		return types.Booking{}, 0, fmt.Errorf(constants.ERROR_STORE_RETRIEVAL,
 			"types.Booking",
 			constants.STORE_BOOKING)
 	}
 
+	if len(booking.BookingID) == 0 {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_NOT_FOUND,
+			msg.BookingID)
+	}
+
 	// renter deduced from signature
 	renter := auth.GetSigner(ctx)
 
 	// only account initiate booking can complete it
 	if !bytes.Equal(renter.GetAddress(), booking.Renter) {
-		return types.Booking{}, fmt.Errorf(constants.BOOKING_MISMATCH_RENTER,
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_MISMATCH_RENTER,
 			utils.ByteToString(booking.Renter),
 			utils.ByteToString(renter.GetAddress()))
 	}
 
-	if booking.IsCompleted == true {
-		return types.Booking{}, fmt.Errorf(constants.BOOKING_COMPLETED_ERROR,
+	if booking.State == types.BOOKING_COMPLETED {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_COMPLETED_ERROR,
+			booking.BookingID)
+	}
+
+	if booking.State == types.BOOKING_CANCELLED {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_CANCELLED_ERROR,
+			booking.BookingID)
+	}
+
+	if booking.HasOpenDispute() {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_DISPUTE_OPEN,
 			booking.BookingID)
 	}
 
+	// A booking can only be completed once its window has started
+	now := ctx.BlockHeader().Time
+	if now < booking.StartTime {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_NOT_STARTED,
+			booking.BookingID,
+			booking.StartTime,
+			now)
+	}
+
+	k.dequeueExpiry(ctx, booking)
+
+	// Start queue is processed at the end of the block, activate it now if needed
+	if booking.State == types.BOOKING_CONFIRMED {
+		k.dequeueStart(ctx, booking.StartTime, booking.BookingID)
+		booking.State = types.BOOKING_ACTIVE
+	}
+
+	if err := booking.Transition(types.BOOKING_COMPLETED); err != nil {
+		return types.Booking{}, 0, err
+	}
+
 	// Check asset
 	var asset types.Asset
 
 	err = utils.Retrieve(assetStore, []byte(booking.UUID), &asset)
 	if err != nil {
-		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_RETRIEVAL,
+		return types.Booking{}, 0, fmt.Errorf(constants.ERROR_STORE_RETRIEVAL,
 			"types.Asset",
 			constants.STORE_ASSET)
 	}
 
-	if asset.Status != false {
-		return types.Booking{}, fmt.Errorf(constants.BOOKING_ASSET_NOT_RENTED,
+	if !asset.Release(booking.BookingID) {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_ASSET_NOT_RENTED,
 			asset.UUID)
 	}
 
-	// Checking owner account
-	ownerAccount := k.accountMapper.GetAccount(ctx, asset.Creator)
-
-	if ownerAccount == nil {
-		ownerAccount = auth.NewSHRAccountWithAddress(asset.Creator)
+	// Unused time of an early return is refunded following the policy agreed when booking
+	refund := booking.GetTerms(asset).GetEarlyReturnRefund(now, booking.EndTime)
+	if refund > booking.Price {
+		refund = booking.Price
 	}
 
-	ownerCoins := ownerAccount.GetCoins()
-
-	// Update owner balance
-	value := booking.Duration * asset.Fee
+	err = k.releaseFromEscrow(ctx, booking.BookingID, booking.Renter,
+		types.NewCoin(booking.GetDenom(), refund))
+	if err != nil {
+		return types.Booking{}, 0, err
+	}
 
-	ownerCoinsAfter := ownerCoins.Plus(types.NewCoin(constants.BOOKING_DENOM, value))
-	constants.LOGGER.Info("Owner balance", "balance", ownerCoinsAfter)
+	// Release payment held in escrow to the current owner
+	err = k.settle(ctx, &booking, asset, booking.Price-refund)
+	if err != nil {
+		return types.Booking{}, 0, err
+	}
+	constants.LOGGER.Info("Owner balance", "balance", k.bankKeeper.GetCoins(ctx, asset.Creator))
 
 	// Update Booking
-	booking.IsCompleted = true
+	booking.CompletedAt = now
+
+	// Deposit stays in escrow until the claim window is over
+	if booking.Deposit > 0 {
+		k.enqueueDeposit(ctx, now+constants.BOOKING_CLAIM_WINDOW, booking.BookingID)
+	} else {
+		booking.DepositSettled = true
+	}
 
-	// Update asset status. Asset is available now
-	asset.Status = true
+	// Asset is available again once no reservation is left
+	asset.Status = len(asset.Calendar) == 0
 
 	// Save asset detail
 	err = utils.Store(assetStore, []byte(asset.UUID), asset)
 
 	if err != nil {
-		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
+		return types.Booking{}, 0, fmt.Errorf(constants.ERROR_STORE_UPDATE,
 			"types.Asset",
 			constants.STORE_ASSET)
 	}
 
 	// Save booking detail
-	err = utils.Store(bookingStore, []byte(asset.UUID), booking)
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, 0, err
+	}
+
+	return booking, refund, nil
 
+}
+
+// Cancel - cancel a booking before its window ends. The refund to the renter is
+// computed at block time from the refund policy agreed when booking, the rest
+// goes to the owner.
+// A booking cancelled by the owner is always refunded in full.
+func (k Keeper) Cancel(ctx sdk.Context, msg msg.MsgCancelBooking) (types.Booking, int64, error) {
+
+	assetStore := ctx.KVStore(k.assetKey)
+
+	booking, found := k.GetBooking(ctx, msg.BookingID)
+	if !found {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_NOT_FOUND,
+			msg.BookingID)
+	}
+
+	if booking.State == types.BOOKING_COMPLETED {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_COMPLETED_ERROR,
+			booking.BookingID)
+	}
+
+	if booking.State == types.BOOKING_CANCELLED {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_CANCELLED_ERROR,
+			booking.BookingID)
+	}
+
+	if booking.HasOpenDispute() {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_DISPUTE_OPEN,
+			booking.BookingID)
+	}
+
+	now := ctx.BlockHeader().Time
+	if now >= booking.EndTime {
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_ALREADY_ENDED,
+			booking.BookingID,
+			booking.EndTime,
+			now)
+	}
+
+	var asset types.Asset
+
+	err := utils.Retrieve(assetStore, []byte(booking.UUID), &asset)
 	if err != nil {
-		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
+		return types.Booking{}, 0, fmt.Errorf(constants.ERROR_STORE_RETRIEVAL,
+			"types.Asset",
+			constants.STORE_ASSET)
+	}
+
+	signer := auth.GetSigner(ctx).GetAddress()
+
+	var refund int64
+	switch {
+	case !bytes.Equal(signer, booking.Renter) && !bytes.Equal(signer, asset.Creator):
+		return types.Booking{}, 0, fmt.Errorf(constants.BOOKING_CANCEL_UNAUTHORIZED,
+			utils.ByteToString(signer),
+			booking.BookingID)
+	case bytes.Equal(signer, booking.Renter) && booking.State != types.BOOKING_REQUESTED:
+		refund = booking.GetTerms(asset).Refund.GetRefund(booking.Price, now, booking.StartTime)
+	default:
+		// Owner cancellation and withdrawn requests are refunded in full
+		refund = booking.Price
+	}
+
+	if booking.State == types.BOOKING_CONFIRMED {
+		k.dequeueStart(ctx, booking.StartTime, booking.BookingID)
+	}
+	k.dequeueExpiry(ctx, booking)
+
+	if err := booking.Transition(types.BOOKING_CANCELLED); err != nil {
+		return types.Booking{}, 0, err
+	}
+
+	// Split escrow between renter and owner. Deposit always returns to renter
+	err = k.releaseFromEscrow(ctx, booking.BookingID, booking.Renter,
+		types.NewCoin(booking.GetDenom(), refund+booking.Deposit))
+	if err != nil {
+		return types.Booking{}, 0, err
+	}
+
+	err = k.settle(ctx, &booking, asset, booking.Price-refund)
+	if err != nil {
+		return types.Booking{}, 0, err
+	}
+
+	booking.DepositSettled = true
+
+	// Free the reserved window
+	asset.Release(booking.BookingID)
+	asset.Status = len(asset.Calendar) == 0
+
+	err = utils.Store(assetStore, []byte(asset.UUID), asset)
+	if err != nil {
+		return types.Booking{}, 0, fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Asset",
+			constants.STORE_ASSET)
+	}
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, 0, err
+	}
+
+	return booking, refund, nil
+}
+
+// ClaimDamage - owner claims part of the deposit of a completed booking within
+// the claim window. The claim pays out once the dispute window has passed.
+func (k Keeper) ClaimDamage(ctx sdk.Context, msg msg.MsgClaimDamage) (types.Booking, error) {
+
+	booking, found := k.GetBooking(ctx, msg.BookingID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_NOT_FOUND,
+			msg.BookingID)
+	}
+
+	if booking.State != types.BOOKING_COMPLETED && booking.State != types.BOOKING_EXPIRED {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_NOT_COMPLETED,
+			booking.BookingID)
+	}
+
+	var asset types.Asset
+
+	err := utils.Retrieve(ctx.KVStore(k.assetKey), []byte(booking.UUID), &asset)
+	if err != nil {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_RETRIEVAL,
+			"types.Asset",
+			constants.STORE_ASSET)
+	}
+
+	if !bytes.Equal(auth.GetSigner(ctx).GetAddress(), asset.Creator) {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_CLAIM_UNAUTHORIZED,
+			utils.ByteToString(asset.Creator),
+			asset.UUID)
+	}
+
+	if booking.Claim != nil {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_CLAIM_EXISTS,
+			booking.BookingID)
+	}
+
+	if booking.HasOpenDispute() {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_DISPUTE_OPEN,
+			booking.BookingID)
+	}
+
+	now := ctx.BlockHeader().Time
+	deadline := booking.CompletedAt + constants.BOOKING_CLAIM_WINDOW
+	if booking.DepositSettled || now >= deadline {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_CLAIM_WINDOW_CLOSED,
+			booking.BookingID,
+			deadline,
+			now)
+	}
+
+	if msg.Amount <= 0 || msg.Amount > booking.Deposit {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_INVALID_CLAIM,
+			msg.Amount,
+			booking.Deposit)
+	}
+
+	claim := types.NewDamageClaim(msg.Amount, msg.Evidence, now)
+	booking.Claim = &claim
+
+	// Postpone settlement to give renter time to dispute the claim
+	k.dequeueDeposit(ctx, deadline, booking.BookingID)
+	k.enqueueDeposit(ctx, now+constants.BOOKING_DISPUTE_WINDOW, booking.BookingID)
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// SettleDeposit - release the deposit of a booking. The claimed amount, if any,
+// goes to the asset owner and the remainder returns to the renter.
+func (k Keeper) SettleDeposit(ctx sdk.Context, bookingID string) (types.Booking, error) {
+
+	booking, found := k.GetBooking(ctx, bookingID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_NOT_FOUND,
+			bookingID)
+	}
+
+	// Disputed deposits stay frozen until the dispute is settled
+	if booking.DepositSettled || booking.HasOpenDispute() {
+		return booking, nil
+	}
+
+	var claimed int64
+	if booking.Claim != nil {
+		var asset types.Asset
+
+		err := utils.Retrieve(ctx.KVStore(k.assetKey), []byte(booking.UUID), &asset)
+		if err != nil {
+			return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_RETRIEVAL,
+				"types.Asset",
+				constants.STORE_ASSET)
+		}
+
+		claimed = booking.Claim.Amount
+
+		err = k.releaseFromEscrow(ctx, booking.BookingID, asset.Creator,
+			types.NewCoin(booking.GetDenom(), claimed))
+		if err != nil {
+			return types.Booking{}, err
+		}
+	}
+
+	err := k.releaseFromEscrow(ctx, booking.BookingID, booking.Renter,
+		types.NewCoin(booking.GetDenom(), booking.Deposit-claimed))
+	if err != nil {
+		return types.Booking{}, err
+	}
+
+	booking.DepositSettled = true
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+//-----------------------------------------------
+// Queues
+
+func (k Keeper) enqueueDeposit(ctx sdk.Context, time int64, bookingID string) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Set(GetDepositQueueKey(time, bookingID), []byte(bookingID))
+}
+
+func (k Keeper) dequeueDeposit(ctx sdk.Context, time int64, bookingID string) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Delete(GetDepositQueueKey(time, bookingID))
+}
+
+func (k Keeper) enqueueDispute(ctx sdk.Context, time int64, bookingID string) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Set(GetDisputeQueueKey(time, bookingID), []byte(bookingID))
+}
+
+func (k Keeper) dequeueDispute(ctx sdk.Context, time int64, bookingID string) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Delete(GetDisputeQueueKey(time, bookingID))
+}
+
+func (k Keeper) enqueueStart(ctx sdk.Context, time int64, bookingID string) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Set(GetStartQueueKey(time, bookingID), []byte(bookingID))
+}
+
+func (k Keeper) dequeueStart(ctx sdk.Context, time int64, bookingID string) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Delete(GetStartQueueKey(time, bookingID))
+}
+
+func (k Keeper) enqueueExpiry(ctx sdk.Context, booking types.Booking) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Set(GetExpiryQueueKey(GetExpiryTime(booking), booking.BookingID), []byte(booking.BookingID))
+}
+
+func (k Keeper) dequeueExpiry(ctx sdk.Context, booking types.Booking) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Delete(GetExpiryQueueKey(GetExpiryTime(booking), booking.BookingID))
+}
+
+// dequeueExpiries - remove and return all bookings to expire at or before now
+func (k Keeper) dequeueExpiries(ctx sdk.Context, now int64) []string {
+	return k.dequeueUntil(ctx, ExpiryQueueKey, now)
+}
+
+// dequeueStarts - remove and return all bookings starting at or before now
+func (k Keeper) dequeueStarts(ctx sdk.Context, now int64) []string {
+	return k.dequeueUntil(ctx, StartQueueKey, now)
+}
+
+// dequeueDeposits - remove and return all deposits due at or before now
+func (k Keeper) dequeueDeposits(ctx sdk.Context, now int64) []string {
+	return k.dequeueUntil(ctx, DepositQueueKey, now)
+}
+
+// dequeueDisputes - remove and return all disputes timing out at or before now
+func (k Keeper) dequeueDisputes(ctx sdk.Context, now int64) []string {
+	return k.dequeueUntil(ctx, DisputeQueueKey, now)
+}
+
+// dequeueUntil - remove and return entries due at or before now, at most
+// BOOKING_QUEUE_LIMIT per call. Remaining entries are handled in later blocks.
+func (k Keeper) dequeueUntil(ctx sdk.Context, prefix []byte, now int64) (bookingIDs []string) {
+	store := ctx.KVStore(k.bookingKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		if getQueueTime(prefix, iterator.Key()) > now || len(keys) >= constants.BOOKING_QUEUE_LIMIT {
+			break
+		}
+		keys = append(keys, iterator.Key())
+		bookingIDs = append(bookingIDs, string(iterator.Value()))
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+
+	return bookingIDs
+}
+
+//-----------------------------------------------
+
+// GetBooking - retrieve a booking by its ID
+func (k Keeper) GetBooking(ctx sdk.Context, bookingID string) (types.Booking, bool) {
+	var booking types.Booking
+
+	err := utils.Retrieve(ctx.KVStore(k.bookingKey), []byte(bookingID), &booking)
+	if err != nil || len(booking.BookingID) == 0 {
+		return types.Booking{}, false
+	}
+
+	return booking, true
+}
+
+// number of bookings created so far
+func (k Keeper) getSequence(ctx sdk.Context) int64 {
+	bz := ctx.KVStore(k.bookingKey).Get(SequenceKey)
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+func (k Keeper) setSequence(ctx sdk.Context, sequence int64) {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(sequence))
+	ctx.KVStore(k.bookingKey).Set(SequenceKey, bz)
+}
+
+// setBooking - save a booking and keep the active index in sync with its state
+func (k Keeper) setBooking(ctx sdk.Context, booking types.Booking) error {
+	store := ctx.KVStore(k.bookingKey)
+
+	err := utils.Store(store, []byte(booking.BookingID), booking)
+	if err != nil {
+		return fmt.Errorf(constants.ERROR_STORE_UPDATE,
 			"types.Booking",
 			constants.STORE_BOOKING)
 	}
 
-	// Save new balance to owner
-	ownerAccount.SetCoins(ownerCoinsAfter)
+	if booking.State == types.BOOKING_ACTIVE {
+		store.Set(GetActiveIndexKey(booking.BookingID), []byte(booking.BookingID))
+	} else {
+		store.Delete(GetActiveIndexKey(booking.BookingID))
+	}
+	return nil
+}
+
+func (k Keeper) getAsset(ctx sdk.Context, uuid string) (types.Asset, error) {
+	var asset types.Asset
+
+	err := utils.Retrieve(ctx.KVStore(k.assetKey), []byte(uuid), &asset)
+	if err != nil {
+		return types.Asset{}, fmt.Errorf(constants.ERROR_STORE_RETRIEVAL,
+			"types.Asset",
+			constants.STORE_ASSET)
+	}
+
+	if len(asset.UUID) == 0 {
+		return types.Asset{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			uuid,
+			constants.STORE_ASSET)
+	}
 
-	// Save account
-	k.accountMapper.SetAccount(ctx, ownerAccount)
+	return asset, nil
+}
 
-	return booking, nil
+// GetBookingPrice - price of renting an asset during [start, end) under its
+// pricing model. Durations outside the model limits are rejected.
+func GetBookingPrice(pricing types.PricingModel, start int64, end int64) (int64, error) {
+	units := pricing.GetUnits(start, end)
+	if !pricing.AllowsUnits(units) {
+		return 0, fmt.Errorf(constants.BOOKING_INVALID_DURATION,
+			units,
+			pricing.MinUnits,
+			pricing.MaxUnits)
+	}
 
+	price, err := pricing.GetPrice(units)
+	if err != nil {
+		return 0, err
+	}
+	return price, nil
 }
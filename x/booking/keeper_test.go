@@ -0,0 +1,1073 @@
+package booking
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/abci/types"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	"bitbucket.org/shareringvn/cosmos-sdk/store"
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"bitbucket.org/shareringvn/cosmos-sdk/wire"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank"
+	"github.com/sharering/shareledger/x/booking/messages"
+)
+
+var (
+	ownerPub, _    = types.GenerateKeyPair()
+	renterPub, _   = types.GenerateKeyPair()
+	strangerPub, _ = types.GenerateKeyPair()
+	arbiterPub, _  = types.GenerateKeyPair()
+	owner          = ownerPub.Address()
+	renter         = renterPub.Address()
+	stranger       = strangerPub.Address()
+	arbiter        = arbiterPub.Address()
+
+	hour = constants.BOOKING_TIME_UNIT
+	now  = int64(1000000)
+)
+
+type testInput struct {
+	ctx      sdk.Context
+	keeper   Keeper
+	am       auth.AccountMapper
+	sk       bank.SupplyKeeper
+	assetKey *sdk.KVStoreKey
+}
+
+func makeTestCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	cdc.RegisterInterface((*auth.BaseAccount)(nil), nil)
+	cdc.RegisterConcrete(&auth.SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterInterface((*types.PubKey)(nil), nil)
+	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
+	return cdc
+}
+
+func setupBookingTest(t *testing.T) testInput {
+	constants.LOGGER = log.NewNopLogger()
+
+	db := dbm.NewMemDB()
+	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
+	assetKey := sdk.NewKVStoreKey(constants.STORE_ASSET)
+	bookingKey := sdk.NewKVStoreKey(constants.STORE_BOOKING)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(authKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(assetKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(bookingKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(bankKey, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: now}, false, log.NewNopLogger())
+
+	cdc := makeTestCodec()
+	am := auth.NewAccountMapper(cdc, authKey, &auth.SHRAccount{})
+	sk := bank.NewSupplyKeeper(bankKey)
+
+	// Renter starts with 1000 SHRP
+	renterAcc := auth.NewSHRAccountWithAddress(renter)
+	renterAcc.SetCoins(renterAcc.Coins.Plus(types.NewCoin(constants.BOOKING_DENOM, 1000)))
+	am.SetAccount(ctx, renterAcc)
+
+	// Asset charged 10 SHRP per hour
+	asset := types.NewAsset("asset-1", owner, []byte("hash"), true, 10)
+	require.Nil(t, utils.Store(ctx.KVStore(assetKey), []byte(asset.UUID), asset))
+
+	return testInput{
+		ctx:      ctx,
+		keeper:   NewKeeper(bookingKey, assetKey, am, sk, cdc),
+		am:       am,
+		sk:       sk,
+		assetKey: assetKey,
+	}
+}
+
+func (in testInput) signedBy(addr sdk.Address) sdk.Context {
+	return auth.WithSigners(in.ctx, auth.NewSHRAccountWithAddress(addr))
+}
+
+func (in testInput) atTime(ctx sdk.Context, time int64) sdk.Context {
+	return ctx.WithBlockHeader(abci.Header{Time: time})
+}
+
+func (in testInput) getAsset(t *testing.T) types.Asset {
+	var asset types.Asset
+	require.Nil(t, utils.Retrieve(in.ctx.KVStore(in.assetKey), []byte("asset-1"), &asset))
+	return asset
+}
+
+func (in testInput) balance(addr sdk.Address) types.Coin {
+	acc := in.am.GetAccount(in.ctx, addr)
+	if acc == nil {
+		return types.NewCoin(constants.BOOKING_DENOM, 0)
+	}
+	return acc.GetCoins().GetCoin(constants.BOOKING_DENOM)
+}
+
+func (in testInput) escrow(bookingID string) types.Coin {
+	return in.balance(GetEscrowAddress(bookingID))
+}
+
+func TestBookingPrice(t *testing.T) {
+	pricing := types.NewAsset("asset-1", owner, nil, true, 10).GetPricing()
+
+	for _, c := range []struct {
+		end   int64
+		price int64
+	}{{1, 10}, {hour, 10}, {hour + 1, 20}} {
+		price, err := GetBookingPrice(pricing, 0, c.end)
+		require.Nil(t, err)
+		require.Equal(t, c.price, price)
+	}
+
+	// Prices beyond the largest amount are refused
+	pricing = types.NewAsset("asset-1", owner, nil, true, math.MaxInt64).GetPricing()
+	_, err := GetBookingPrice(pricing, 0, hour+1)
+	require.NotNil(t, err)
+}
+
+func TestBookNonOverlappingWindows(t *testing.T) {
+	in := setupBookingTest(t)
+	ctx := in.signedBy(renter)
+
+	first, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+	require.Equal(t, int64(10), first.Price)
+
+	// Adjacent window shares only the boundary
+	_, err = in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+2*hour, now+4*hour))
+	require.Nil(t, err)
+
+	asset := in.getAsset(t)
+	require.Len(t, asset.Calendar, 2)
+	require.False(t, asset.Status)
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 970)))
+	require.True(t, in.escrow(first.BookingID).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)))
+}
+
+func TestBookOverlappingWindow(t *testing.T) {
+	in := setupBookingTest(t)
+	ctx := in.signedBy(renter)
+
+	_, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+3*hour))
+	require.Nil(t, err)
+
+	_, err = in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+2*hour, now+5*hour))
+	require.NotNil(t, err)
+
+	require.Len(t, in.getAsset(t).Calendar, 1)
+}
+
+func TestBookInThePast(t *testing.T) {
+	in := setupBookingTest(t)
+
+	_, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now-hour, now+hour))
+	require.NotNil(t, err)
+}
+
+func TestCompleteBooking(t *testing.T) {
+	in := setupBookingTest(t)
+	ctx := in.signedBy(renter)
+
+	booking, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+	require.True(t, in.escrow(booking.BookingID).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)))
+
+	// Window has not started yet
+	_, _, err = in.keeper.Complete(ctx, messages.NewMsgComplete(booking.BookingID))
+	require.NotNil(t, err)
+
+	booking, _, err = in.keeper.Complete(in.atTime(ctx, now+hour), messages.NewMsgComplete(booking.BookingID))
+	require.Nil(t, err)
+	require.Equal(t, types.BOOKING_COMPLETED, booking.State)
+
+	asset := in.getAsset(t)
+	require.Len(t, asset.Calendar, 0)
+	require.True(t, asset.Status)
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)))
+	require.True(t, in.escrow(booking.BookingID).IsZero())
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 990)))
+}
+
+func (in testInput) setRefundPolicy(t *testing.T, policy types.RefundPolicy) {
+	asset := in.getAsset(t)
+	asset.Refund = policy
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+}
+
+func TestCancelBeforeStart(t *testing.T) {
+	in := setupBookingTest(t)
+	ctx := in.signedBy(renter)
+
+	booking, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+3*hour))
+	require.Nil(t, err)
+
+	booking, refund, err := in.keeper.Cancel(ctx, messages.NewMsgCancelBooking(booking.BookingID))
+	require.Nil(t, err)
+	require.Equal(t, types.BOOKING_CANCELLED, booking.State)
+	require.Equal(t, int64(20), refund)
+
+	asset := in.getAsset(t)
+	require.Len(t, asset.Calendar, 0)
+	require.True(t, asset.Status)
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 1000)))
+	require.True(t, in.escrow(booking.BookingID).IsZero())
+
+	// Cancelled bookings can be neither cancelled nor completed again
+	_, _, err = in.keeper.Cancel(ctx, messages.NewMsgCancelBooking(booking.BookingID))
+	require.NotNil(t, err)
+	_, _, err = in.keeper.Complete(in.atTime(ctx, now+hour), messages.NewMsgComplete(booking.BookingID))
+	require.NotNil(t, err)
+}
+
+func TestCancelAfterStartPartialRefund(t *testing.T) {
+	in := setupBookingTest(t)
+	in.setRefundPolicy(t, types.NewRefundPolicy(types.REFUND_PARTIAL, 50))
+	ctx := in.signedBy(renter)
+
+	booking, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+3*hour))
+	require.Nil(t, err)
+
+	_, refund, err := in.keeper.Cancel(in.atTime(ctx, now+2*hour), messages.NewMsgCancelBooking(booking.BookingID))
+	require.Nil(t, err)
+	require.Equal(t, int64(10), refund)
+
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 990)))
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)))
+}
+
+func TestCancelByOwnerAndStranger(t *testing.T) {
+	in := setupBookingTest(t)
+	in.setRefundPolicy(t, types.NewRefundPolicy(types.REFUND_NONE, 0))
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+3*hour))
+	require.Nil(t, err)
+
+	_, _, err = in.keeper.Cancel(in.signedBy(stranger), messages.NewMsgCancelBooking(booking.BookingID))
+	require.NotNil(t, err)
+
+	// Owner cancellation always refunds the renter in full
+	_, refund, err := in.keeper.Cancel(in.signedBy(owner), messages.NewMsgCancelBooking(booking.BookingID))
+	require.Nil(t, err)
+	require.Equal(t, int64(20), refund)
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 1000)))
+}
+
+func TestCancelUsesPolicyAgreedWhenBooked(t *testing.T) {
+	in := setupBookingTest(t)
+	in.setRefundPolicy(t, types.NewRefundPolicy(types.REFUND_PARTIAL, 50))
+	ctx := in.signedBy(renter)
+
+	booking, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+3*hour))
+	require.Nil(t, err)
+
+	// Tightening the policy afterwards does not apply to the booking
+	in.setRefundPolicy(t, types.NewRefundPolicy(types.REFUND_NONE, 0))
+
+	_, refund, err := in.keeper.Cancel(in.atTime(ctx, now+2*hour), messages.NewMsgCancelBooking(booking.BookingID))
+	require.Nil(t, err)
+	require.Equal(t, int64(10), refund)
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 990)))
+}
+
+func TestDepositReturnedWithoutClaim(t *testing.T) {
+	in := setupBookingTest(t)
+
+	asset := in.getAsset(t)
+	asset.Deposit = 100
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+
+	ctx := in.signedBy(renter)
+	booking, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+	require.True(t, in.escrow(booking.BookingID).Equal(types.NewCoin(constants.BOOKING_DENOM, 110)))
+
+	_, _, err = in.keeper.Complete(in.atTime(ctx, now+2*hour), messages.NewMsgComplete(booking.BookingID))
+	require.Nil(t, err)
+
+	// Nothing happens before the claim window closes
+	EndBlocker(in.atTime(ctx, now+2*hour+constants.BOOKING_CLAIM_WINDOW-1), in.keeper)
+	require.True(t, in.escrow(booking.BookingID).Equal(types.NewCoin(constants.BOOKING_DENOM, 100)))
+
+	EndBlocker(in.atTime(ctx, now+2*hour+constants.BOOKING_CLAIM_WINDOW), in.keeper)
+	require.True(t, in.escrow(booking.BookingID).IsZero())
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 990)))
+}
+
+func TestDamageClaimPaysOwner(t *testing.T) {
+	in := setupBookingTest(t)
+
+	asset := in.getAsset(t)
+	asset.Deposit = 100
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	completed := now + 2*hour
+	_, _, err = in.keeper.Complete(in.atTime(in.signedBy(renter), completed), messages.NewMsgComplete(booking.BookingID))
+	require.Nil(t, err)
+
+	claim := messages.NewMsgClaimDamage(booking.BookingID, 60, [][]byte{[]byte("photo-hash")})
+
+	// Only owner can claim, and not more than the deposit
+	_, err = in.keeper.ClaimDamage(in.atTime(in.signedBy(renter), completed+1), claim)
+	require.NotNil(t, err)
+	_, err = in.keeper.ClaimDamage(in.atTime(in.signedBy(owner), completed+1),
+		messages.NewMsgClaimDamage(booking.BookingID, 101, [][]byte{[]byte("photo-hash")}))
+	require.NotNil(t, err)
+
+	_, err = in.keeper.ClaimDamage(in.atTime(in.signedBy(owner), completed+1), claim)
+	require.Nil(t, err)
+
+	// Original claim window no longer releases the deposit
+	EndBlocker(in.atTime(in.ctx, completed+constants.BOOKING_CLAIM_WINDOW), in.keeper)
+	require.True(t, in.escrow(booking.BookingID).Equal(types.NewCoin(constants.BOOKING_DENOM, 100)))
+
+	EndBlocker(in.atTime(in.ctx, completed+1+constants.BOOKING_DISPUTE_WINDOW), in.keeper)
+	require.True(t, in.escrow(booking.BookingID).IsZero())
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 70)))
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 930)))
+}
+
+func (in testInput) setDepositAndArbiter(t *testing.T, deposit int64, arbiter sdk.Address) {
+	asset := in.getAsset(t)
+	asset.Deposit = deposit
+	asset.Arbiter = arbiter
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+}
+
+func TestDisputeResolvedByArbiter(t *testing.T) {
+	in := setupBookingTest(t)
+	in.setDepositAndArbiter(t, 100, arbiter)
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	// Strangers cannot open a dispute
+	_, err = in.keeper.OpenDispute(in.signedBy(stranger), messages.NewMsgOpenDispute(booking.BookingID, "broken"))
+	require.NotNil(t, err)
+
+	_, err = in.keeper.OpenDispute(in.signedBy(renter), messages.NewMsgOpenDispute(booking.BookingID, "broken"))
+	require.Nil(t, err)
+
+	// Funds are frozen while the dispute is open
+	_, _, err = in.keeper.Complete(in.atTime(in.signedBy(renter), now+2*hour), messages.NewMsgComplete(booking.BookingID))
+	require.NotNil(t, err)
+	_, _, err = in.keeper.Cancel(in.signedBy(renter), messages.NewMsgCancelBooking(booking.BookingID))
+	require.NotNil(t, err)
+
+	// Only an arbiter can rule, within the escrowed amount
+	_, _, err = in.keeper.ResolveDispute(in.signedBy(owner), messages.NewMsgResolveDispute(booking.BookingID, 0))
+	require.NotNil(t, err)
+	_, _, err = in.keeper.ResolveDispute(in.signedBy(arbiter), messages.NewMsgResolveDispute(booking.BookingID, 111))
+	require.NotNil(t, err)
+
+	booking, ownerAmount, err := in.keeper.ResolveDispute(in.signedBy(arbiter), messages.NewMsgResolveDispute(booking.BookingID, 105))
+	require.Nil(t, err)
+	require.Equal(t, int64(5), ownerAmount)
+	require.Equal(t, types.BOOKING_COMPLETED, booking.State)
+	require.True(t, booking.DepositSettled)
+	require.False(t, booking.HasOpenDispute())
+
+	require.True(t, in.escrow(booking.BookingID).IsZero())
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 995)))
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 5)))
+	require.True(t, in.getAsset(t).Status)
+
+	// A booking can only be disputed once
+	_, err = in.keeper.OpenDispute(in.signedBy(owner), messages.NewMsgOpenDispute(booking.BookingID, "again"))
+	require.NotNil(t, err)
+}
+
+func TestDisputeTimesOut(t *testing.T) {
+	in := setupBookingTest(t)
+	in.setDepositAndArbiter(t, 100, nil)
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	completed := now + 2*hour
+	_, _, err = in.keeper.Complete(in.atTime(in.signedBy(renter), completed), messages.NewMsgComplete(booking.BookingID))
+	require.Nil(t, err)
+
+	_, err = in.keeper.ClaimDamage(in.atTime(in.signedBy(owner), completed+1),
+		messages.NewMsgClaimDamage(booking.BookingID, 60, [][]byte{[]byte("photo-hash")}))
+	require.Nil(t, err)
+
+	opened := completed + 2
+	_, err = in.keeper.OpenDispute(in.atTime(in.signedBy(renter), opened), messages.NewMsgOpenDispute(booking.BookingID, "no damage"))
+	require.Nil(t, err)
+
+	// Deposit stays frozen past the claim dispute window
+	EndBlocker(in.atTime(in.ctx, completed+1+constants.BOOKING_DISPUTE_WINDOW), in.keeper)
+	require.True(t, in.escrow(booking.BookingID).Equal(types.NewCoin(constants.BOOKING_DENOM, 100)))
+
+	// Without a ruling the claim is paid as filed
+	EndBlocker(in.atTime(in.ctx, opened+constants.BOOKING_DISPUTE_TIMEOUT), in.keeper)
+	require.True(t, in.escrow(booking.BookingID).IsZero())
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 70)))
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 930)))
+
+	booking, _ = in.keeper.GetBooking(in.ctx, booking.BookingID)
+	require.True(t, booking.Dispute.Resolved)
+	require.Nil(t, booking.Dispute.ResolvedBy)
+}
+
+// setGlobalArbiters - grant the arbiter role to addrs at genesis
+func (in testInput) setGlobalArbiters(t *testing.T, addrs ...sdk.Address) {
+	data := bank.DefaultGenesisState()
+	for _, addr := range addrs {
+		data.Authorities = append(data.Authorities, types.NewAuthority(addr, []string{types.AUTHORITY_ARBITER}, nil, nil))
+	}
+	require.Nil(t, bank.InitGenesis(in.ctx, in.sk, data))
+}
+
+func TestDisputeResolvedByGlobalArbiter(t *testing.T) {
+	in := setupBookingTest(t)
+	in.setDepositAndArbiter(t, 100, nil)
+	in.setGlobalArbiters(t, arbiter, renter, owner)
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+	_, err = in.keeper.OpenDispute(in.signedBy(owner), messages.NewMsgOpenDispute(booking.BookingID, "broken"))
+	require.Nil(t, err)
+
+	// Global arbiters cannot rule on their own bookings or assets
+	_, _, err = in.keeper.ResolveDispute(in.signedBy(renter), messages.NewMsgResolveDispute(booking.BookingID, 110))
+	require.NotNil(t, err)
+	_, _, err = in.keeper.ResolveDispute(in.signedBy(owner), messages.NewMsgResolveDispute(booking.BookingID, 0))
+	require.NotNil(t, err)
+	_, _, err = in.keeper.ResolveDispute(in.signedBy(stranger), messages.NewMsgResolveDispute(booking.BookingID, 0))
+	require.NotNil(t, err)
+
+	booking, _, err = in.keeper.ResolveDispute(in.signedBy(arbiter), messages.NewMsgResolveDispute(booking.BookingID, 110))
+	require.Nil(t, err)
+	require.Equal(t, arbiter, booking.Dispute.ResolvedBy)
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 1000)))
+}
+
+func TestDisputeArbiterFixedWhenOpened(t *testing.T) {
+	in := setupBookingTest(t)
+	in.setDepositAndArbiter(t, 100, arbiter)
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	_, err = in.keeper.OpenDispute(in.signedBy(renter), messages.NewMsgOpenDispute(booking.BookingID, "broken"))
+	require.Nil(t, err)
+	require.Equal(t, int64(1), in.getAsset(t).OpenDisputes)
+
+	// Naming the owner arbiter of the asset afterwards gives no say in the dispute
+	in.setDepositAndArbiter(t, 100, owner)
+
+	_, _, err = in.keeper.ResolveDispute(in.signedBy(owner), messages.NewMsgResolveDispute(booking.BookingID, 0))
+	require.NotNil(t, err)
+
+	_, _, err = in.keeper.ResolveDispute(in.signedBy(arbiter), messages.NewMsgResolveDispute(booking.BookingID, 110))
+	require.Nil(t, err)
+	require.Equal(t, int64(0), in.getAsset(t).OpenDisputes)
+}
+
+func TestArbiterCannotBook(t *testing.T) {
+	in := setupBookingTest(t)
+	in.setDepositAndArbiter(t, 0, renter)
+
+	_, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.NotNil(t, err)
+}
+
+func TestBookingIDsAreUnique(t *testing.T) {
+	in := setupBookingTest(t)
+	ctx := in.signedBy(renter)
+
+	// Same asset and same duration used to produce the same ID
+	first, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+	second, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+2*hour, now+3*hour))
+	require.Nil(t, err)
+
+	require.Len(t, first.BookingID, 64)
+	require.NotEqual(t, first.BookingID, second.BookingID)
+}
+
+func TestBookingIDCollisionRejected(t *testing.T) {
+	in := setupBookingTest(t)
+	ctx := in.signedBy(renter)
+
+	msg := messages.NewMsgBook("asset-1", now+hour, now+2*hour)
+	id, err := GetBookingID(ctx.BlockHeight(), 0, renter, 0, msg)
+	require.Nil(t, err)
+
+	taken := types.NewBooking(id, stranger, "asset-1", now, now+hour, 10, types.BOOKING_CONFIRMED)
+	require.Nil(t, utils.Store(ctx.KVStore(in.keeper.bookingKey), []byte(id), taken))
+
+	_, err = in.keeper.Book(ctx, msg)
+	require.NotNil(t, err)
+
+	booking, found := in.keeper.GetBooking(ctx, id)
+	require.True(t, found)
+	require.Equal(t, stranger, booking.Renter)
+}
+
+func TestLegacyBookingIDResolves(t *testing.T) {
+	in := setupBookingTest(t)
+
+	legacy := types.NewBooking("a1b2", renter, "asset-1", now, now+hour, 10, types.BOOKING_CONFIRMED)
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.keeper.bookingKey), []byte("a1b2"), legacy))
+
+	booking, found := in.keeper.GetBooking(in.ctx, "a1b2")
+	require.True(t, found)
+	require.Equal(t, legacy.BookingID, booking.BookingID)
+}
+
+func (in testInput) requireApproval(t *testing.T) {
+	asset := in.getAsset(t)
+	asset.ApprovalRequired = true
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+}
+
+func TestInstantBookingActivates(t *testing.T) {
+	in := setupBookingTest(t)
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+	require.Equal(t, types.BOOKING_CONFIRMED, booking.State)
+
+	EndBlocker(in.atTime(in.ctx, now+hour-1), in.keeper)
+	booking, _ = in.keeper.GetBooking(in.ctx, booking.BookingID)
+	require.Equal(t, types.BOOKING_CONFIRMED, booking.State)
+
+	resTags := EndBlocker(in.atTime(in.ctx, now+hour), in.keeper)
+	require.Len(t, resTags, 3)
+	booking, _ = in.keeper.GetBooking(in.ctx, booking.BookingID)
+	require.Equal(t, types.BOOKING_ACTIVE, booking.State)
+}
+
+func TestApproveBooking(t *testing.T) {
+	in := setupBookingTest(t)
+	in.requireApproval(t)
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+	require.Equal(t, types.BOOKING_REQUESTED, booking.State)
+
+	// Requested window is held, payment is in escrow
+	require.Len(t, in.getAsset(t).Calendar, 1)
+	require.True(t, in.escrow(booking.BookingID).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)))
+
+	// Requested bookings cannot start
+	_, _, err = in.keeper.Complete(in.atTime(in.signedBy(renter), now+hour), messages.NewMsgComplete(booking.BookingID))
+	require.NotNil(t, err)
+
+	// Only owner approves, and only before the start time
+	_, err = in.keeper.Approve(in.signedBy(renter), messages.NewMsgApproveBooking(booking.BookingID))
+	require.NotNil(t, err)
+	_, err = in.keeper.Approve(in.atTime(in.signedBy(owner), now+hour), messages.NewMsgApproveBooking(booking.BookingID))
+	require.NotNil(t, err)
+
+	booking, err = in.keeper.Approve(in.signedBy(owner), messages.NewMsgApproveBooking(booking.BookingID))
+	require.Nil(t, err)
+	require.Equal(t, types.BOOKING_CONFIRMED, booking.State)
+
+	_, err = in.keeper.Reject(in.signedBy(owner), messages.NewMsgRejectBooking(booking.BookingID))
+	require.NotNil(t, err)
+
+	booking, _, err = in.keeper.Complete(in.atTime(in.signedBy(renter), now+2*hour), messages.NewMsgComplete(booking.BookingID))
+	require.Nil(t, err)
+	require.Equal(t, types.BOOKING_COMPLETED, booking.State)
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)))
+}
+
+func TestRejectBooking(t *testing.T) {
+	in := setupBookingTest(t)
+	in.requireApproval(t)
+	in.setDepositAndArbiter(t, 100, nil)
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	_, err = in.keeper.Reject(in.signedBy(stranger), messages.NewMsgRejectBooking(booking.BookingID))
+	require.NotNil(t, err)
+
+	booking, err = in.keeper.Reject(in.signedBy(owner), messages.NewMsgRejectBooking(booking.BookingID))
+	require.Nil(t, err)
+	require.Equal(t, types.BOOKING_CANCELLED, booking.State)
+
+	asset := in.getAsset(t)
+	require.Len(t, asset.Calendar, 0)
+	require.True(t, asset.Status)
+	require.True(t, in.escrow(booking.BookingID).IsZero())
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 1000)))
+
+	_, err = in.keeper.Approve(in.signedBy(owner), messages.NewMsgApproveBooking(booking.BookingID))
+	require.NotNil(t, err)
+}
+
+func TestOverdueBookingExpires(t *testing.T) {
+	in := setupBookingTest(t)
+	in.setDepositAndArbiter(t, 100, nil)
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	overdue := now + 2*hour + constants.BOOKING_GRACE_PERIOD
+
+	EndBlocker(in.atTime(in.ctx, overdue-1), in.keeper)
+	booking, _ = in.keeper.GetBooking(in.ctx, booking.BookingID)
+	require.Equal(t, types.BOOKING_ACTIVE, booking.State)
+
+	EndBlocker(in.atTime(in.ctx, overdue), in.keeper)
+	booking, _ = in.keeper.GetBooking(in.ctx, booking.BookingID)
+	require.Equal(t, types.BOOKING_EXPIRED, booking.State)
+
+	// Owner is paid and the asset is free again, deposit waits for claims
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)))
+	require.True(t, in.escrow(booking.BookingID).Equal(types.NewCoin(constants.BOOKING_DENOM, 100)))
+	require.True(t, in.getAsset(t).Status)
+
+	_, err = in.keeper.ClaimDamage(in.atTime(in.signedBy(owner), overdue+1),
+		messages.NewMsgClaimDamage(booking.BookingID, 100, [][]byte{[]byte("missing")}))
+	require.Nil(t, err)
+}
+
+func TestLateFeeCharged(t *testing.T) {
+	in := setupBookingTest(t)
+
+	asset := in.getAsset(t)
+	asset.LateFee = 400
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	overdue := now + 2*hour + constants.BOOKING_GRACE_PERIOD
+
+	// Renter has 990 left, enough for two periods
+	for i := int64(0); i < 2; i++ {
+		EndBlocker(in.atTime(in.ctx, overdue+i*hour), in.keeper)
+		booking, _ = in.keeper.GetBooking(in.ctx, booking.BookingID)
+		require.Equal(t, types.BOOKING_ACTIVE, booking.State)
+		require.Equal(t, i+1, booking.LatePeriods)
+	}
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 190)))
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 800)))
+
+	EndBlocker(in.atTime(in.ctx, overdue+2*hour), in.keeper)
+	booking, _ = in.keeper.GetBooking(in.ctx, booking.BookingID)
+	require.Equal(t, types.BOOKING_EXPIRED, booking.State)
+	require.Equal(t, int64(800), booking.LateFees)
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 810)))
+}
+
+func TestLateFeeSettled(t *testing.T) {
+	in := setupBookingTest(t)
+
+	treasuryPub, _ := types.GenerateKeyPair()
+	treasury := treasuryPub.Address()
+	in.keeper.SetParams(in.ctx, Params{
+		Commission:    types.NewDecWithPrec(1, 1), // 10%
+		Treasury:      treasury,
+		ReferralShare: types.ZeroDec(),
+	})
+
+	asset := in.getAsset(t)
+	asset.LateFee = 400
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	// Late fee pays the commission, the price stays in escrow
+	EndBlocker(in.atTime(in.ctx, now+2*hour+constants.BOOKING_GRACE_PERIOD), in.keeper)
+	booking, _ = in.keeper.GetBooking(in.ctx, booking.BookingID)
+	require.Len(t, booking.Payouts, 2)
+
+	denom := constants.BOOKING_DENOM
+	require.True(t, in.balance(owner).Equal(types.NewCoin(denom, 360)))
+	require.True(t, in.balance(treasury).Equal(types.NewCoin(denom, 40)))
+	require.True(t, in.escrow(booking.BookingID).Equal(types.NewCoin(denom, 10)))
+}
+
+func TestRequestExpires(t *testing.T) {
+	in := setupBookingTest(t)
+	in.requireApproval(t)
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	EndBlocker(in.atTime(in.ctx, now+hour), in.keeper)
+	booking, _ = in.keeper.GetBooking(in.ctx, booking.BookingID)
+	require.Equal(t, types.BOOKING_EXPIRED, booking.State)
+
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 1000)))
+	require.Len(t, in.getAsset(t).Calendar, 0)
+}
+
+func TestExtendBooking(t *testing.T) {
+	in := setupBookingTest(t)
+	ctx := in.signedBy(renter)
+
+	booking, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+	_, err = in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+4*hour, now+5*hour))
+	require.Nil(t, err)
+
+	// Only renter extends, and not into the next reservation
+	_, _, err = in.keeper.Extend(in.signedBy(owner), messages.NewMsgExtendBooking(booking.BookingID, 1))
+	require.NotNil(t, err)
+	_, _, err = in.keeper.Extend(ctx, messages.NewMsgExtendBooking(booking.BookingID, 3))
+	require.NotNil(t, err)
+
+	booking, charged, err := in.keeper.Extend(ctx, messages.NewMsgExtendBooking(booking.BookingID, 2))
+	require.Nil(t, err)
+	require.Equal(t, int64(20), charged)
+	require.Equal(t, now+4*hour, booking.EndTime)
+	require.Equal(t, int64(30), booking.Price)
+	require.True(t, in.escrow(booking.BookingID).Equal(types.NewCoin(constants.BOOKING_DENOM, 30)))
+
+	// Overdue check follows the new end time
+	EndBlocker(in.atTime(in.ctx, now+2*hour+constants.BOOKING_GRACE_PERIOD), in.keeper)
+	booking, _ = in.keeper.GetBooking(in.ctx, booking.BookingID)
+	require.Equal(t, types.BOOKING_ACTIVE, booking.State)
+}
+
+func TestEarlyReturn(t *testing.T) {
+	in := setupBookingTest(t)
+
+	asset := in.getAsset(t)
+	asset.EarlyReturn = 50
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+
+	ctx := in.signedBy(renter)
+	booking, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+5*hour))
+	require.Nil(t, err)
+
+	// Two whole hours unused, the started one is paid
+	booking, refund, err := in.keeper.Complete(in.atTime(ctx, now+2*hour+1), messages.NewMsgComplete(booking.BookingID))
+	require.Nil(t, err)
+	require.Equal(t, int64(10), refund)
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 30)))
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 970)))
+}
+
+func TestEarlyReturnUsesPolicyAgreedWhenBooked(t *testing.T) {
+	in := setupBookingTest(t)
+
+	asset := in.getAsset(t)
+	asset.EarlyReturn = 50
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+
+	ctx := in.signedBy(renter)
+	booking, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+5*hour))
+	require.Nil(t, err)
+
+	// Dropping the refund afterwards does not apply to the booking
+	asset = in.getAsset(t)
+	asset.EarlyReturn = 0
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+
+	_, refund, err := in.keeper.Complete(in.atTime(ctx, now+2*hour+1), messages.NewMsgComplete(booking.BookingID))
+	require.Nil(t, err)
+	require.Equal(t, int64(10), refund)
+}
+
+func TestSettlementSplit(t *testing.T) {
+	in := setupBookingTest(t)
+
+	treasuryPub, _ := types.GenerateKeyPair()
+	referrerPub, _ := types.GenerateKeyPair()
+	treasury, referrer := treasuryPub.Address(), referrerPub.Address()
+
+	params := Params{
+		Commission:    types.NewDecWithPrec(1, 1), // 10%
+		Treasury:      treasury,
+		ReferralShare: types.NewDecWithPrec(5, 2), // 5%
+	}
+	require.Nil(t, params.Validate())
+	in.keeper.SetParams(in.ctx, params)
+
+	ctx := in.signedBy(renter)
+
+	msg := messages.NewMsgBook("asset-1", now+hour, now+2*hour)
+	msg.Referrer = renter
+	_, err := in.keeper.Book(ctx, msg)
+	require.NotNil(t, err)
+
+	msg.Referrer = referrer
+	booking, err := in.keeper.Book(ctx, msg)
+	require.Nil(t, err)
+
+	booking, _, err = in.keeper.Complete(in.atTime(ctx, now+2*hour), messages.NewMsgComplete(booking.BookingID))
+	require.Nil(t, err)
+	require.Len(t, booking.Payouts, 3)
+
+	denom := constants.BOOKING_DENOM
+	require.True(t, in.balance(owner).Equal(types.NewCoinFromDec(denom, types.NewDecWithPrec(85, 1))))
+	require.True(t, in.balance(treasury).Equal(types.NewCoin(denom, 1)))
+	require.True(t, in.balance(referrer).Equal(types.NewCoinFromDec(denom, types.NewDecWithPrec(5, 1))))
+	require.True(t, in.escrow(booking.BookingID).IsZero())
+}
+
+func TestParamsValidation(t *testing.T) {
+	require.Nil(t, DefaultParams().Validate())
+
+	// Commission needs a treasury
+	params := DefaultParams()
+	params.Commission = types.NewDecWithPrec(1, 1)
+	require.NotNil(t, params.Validate())
+
+	// Shares cannot exceed the revenue
+	params.Treasury = owner
+	params.ReferralShare = types.NewDecWithPrec(95, 2)
+	require.NotNil(t, params.Validate())
+}
+
+func TestRateCompletedBooking(t *testing.T) {
+	in := setupBookingTest(t)
+	ctx := in.signedBy(renter)
+
+	booking, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	// Only completed bookings can be rated
+	_, _, err = in.keeper.Rate(ctx, messages.NewMsgRate(booking.BookingID, 5, nil))
+	require.NotNil(t, err)
+
+	_, _, err = in.keeper.Complete(in.atTime(ctx, now+2*hour), messages.NewMsgComplete(booking.BookingID))
+	require.Nil(t, err)
+
+	_, _, err = in.keeper.Rate(in.signedBy(stranger), messages.NewMsgRate(booking.BookingID, 1, nil))
+	require.NotNil(t, err)
+
+	_, rated, err := in.keeper.Rate(ctx, messages.NewMsgRate(booking.BookingID, 4, []byte("review-hash")))
+	require.Nil(t, err)
+	require.Equal(t, owner, rated)
+
+	// Once per party
+	_, _, err = in.keeper.Rate(ctx, messages.NewMsgRate(booking.BookingID, 5, nil))
+	require.NotNil(t, err)
+
+	_, rated, err = in.keeper.Rate(in.signedBy(owner), messages.NewMsgRate(booking.BookingID, 3, nil))
+	require.Nil(t, err)
+	require.Equal(t, renter, rated)
+
+	require.Equal(t, types.Reputation{Count: 1, Total: 4}, in.keeper.GetReputation(in.ctx, owner))
+	require.Equal(t, types.Reputation{Count: 1, Total: 3}, in.keeper.GetReputation(in.ctx, renter))
+	require.Equal(t, types.Reputation{Count: 1, Total: 4}, in.keeper.GetAssetRating(in.ctx, "asset-1"))
+}
+
+func TestBookingIndexes(t *testing.T) {
+	in := setupBookingTest(t)
+	ctx := in.signedBy(renter)
+
+	first, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+	second, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+2*hour, now+3*hour))
+	require.Nil(t, err)
+
+	require.Len(t, in.keeper.GetBookingsByRenter(in.ctx, renter, 0, 0), 2)
+	require.Len(t, in.keeper.GetBookingsByRenter(in.ctx, stranger, 0, 0), 0)
+	require.Len(t, in.keeper.GetBookingsByAsset(in.ctx, "asset-1", 0, 0), 2)
+
+	// One booking per page
+	page1 := in.keeper.GetBookingsByAsset(in.ctx, "asset-1", 1, 1)
+	page2 := in.keeper.GetBookingsByAsset(in.ctx, "asset-1", 2, 1)
+	require.Len(t, page1, 1)
+	require.Len(t, page2, 1)
+	require.NotEqual(t, page1[0].BookingID, page2[0].BookingID)
+	require.Len(t, in.keeper.GetBookingsByAsset(in.ctx, "asset-1", 3, 1), 0)
+
+	// Active index follows the state of each booking
+	require.Len(t, in.keeper.GetActiveBookings(in.ctx, 0, 0), 0)
+
+	EndBlocker(in.atTime(in.ctx, now+hour), in.keeper)
+	active := in.keeper.GetActiveBookings(in.ctx, 0, 0)
+	require.Len(t, active, 1)
+	require.Equal(t, first.BookingID, active[0].BookingID)
+
+	_, _, err = in.keeper.Complete(in.atTime(ctx, now+2*hour), messages.NewMsgComplete(first.BookingID))
+	require.Nil(t, err)
+	require.Len(t, in.keeper.GetActiveBookings(in.ctx, 0, 0), 0)
+
+	_, _, err = in.keeper.Cancel(in.atTime(ctx, now+2*hour), messages.NewMsgCancelBooking(second.BookingID))
+	require.Nil(t, err)
+	require.Len(t, in.keeper.GetActiveBookings(in.ctx, 0, 0), 0)
+	require.Len(t, in.keeper.GetBookingsByRenter(in.ctx, renter, 0, 0), 2)
+}
+
+func TestPricingModel(t *testing.T) {
+	in := setupBookingTest(t)
+
+	// 100 per day, 2 to 10 days, 10% off from 7 days, paid in SHR
+	day := 24 * hour
+	pricing := types.NewPricingModel(constants.POS_DENOM, day, types.NewDec(100), 2, 10,
+		[]types.DiscountTier{types.NewDiscountTier(7, types.NewDecWithPrec(1, 1))})
+
+	asset := in.getAsset(t)
+	asset.Pricing = &pricing
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+
+	renterAcc := in.am.GetAccount(in.ctx, renter)
+	renterAcc.SetCoins(renterAcc.GetCoins().Plus(types.NewCoin(constants.POS_DENOM, 1000)))
+	in.am.SetAccount(in.ctx, renterAcc)
+
+	ctx := in.signedBy(renter)
+
+	// Outside duration limits
+	_, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+hour+day))
+	require.NotNil(t, err)
+	_, err = in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+hour+11*day))
+	require.NotNil(t, err)
+
+	booking, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+hour+8*day))
+	require.Nil(t, err)
+	require.Equal(t, constants.POS_DENOM, booking.Denom)
+	require.Equal(t, int64(720), booking.Price)
+
+	escrow := in.keeper.GetEscrowBalance(in.ctx, booking.BookingID)
+	require.True(t, escrow.Coins.GetCoin(constants.POS_DENOM).Equal(types.NewCoin(constants.POS_DENOM, 720)))
+	require.True(t, in.balance(renter).Equal(types.NewCoin(constants.BOOKING_DENOM, 1000)))
+
+	// Extension is charged for the extra day only
+	booking, charged, err := in.keeper.Extend(ctx, messages.NewMsgExtendBooking(booking.BookingID, 1))
+	require.Nil(t, err)
+	require.Equal(t, int64(100), charged)
+	require.Equal(t, int64(820), booking.Price)
+
+	// Beyond the maximum duration
+	_, _, err = in.keeper.Extend(ctx, messages.NewMsgExtendBooking(booking.BookingID, 2))
+	require.NotNil(t, err)
+
+	_, _, err = in.keeper.Complete(in.atTime(ctx, now+hour+9*day), messages.NewMsgComplete(booking.BookingID))
+	require.Nil(t, err)
+	require.True(t, in.am.GetAccount(in.ctx, owner).GetCoins().GetCoin(constants.POS_DENOM).
+		Equal(types.NewCoin(constants.POS_DENOM, 820)))
+}
+
+func TestExtendUsesPricingAgreedWhenBooked(t *testing.T) {
+	in := setupBookingTest(t)
+	ctx := in.signedBy(renter)
+
+	booking, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	// Raising the fee afterwards does not apply to the booking
+	asset := in.getAsset(t)
+	asset.Fee = 50
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+
+	booking, charged, err := in.keeper.Extend(ctx, messages.NewMsgExtendBooking(booking.BookingID, 1))
+	require.Nil(t, err)
+	require.Equal(t, int64(10), charged)
+	require.Equal(t, int64(20), booking.Price)
+}
+
+func TestOperatorApprovesBooking(t *testing.T) {
+	in := setupBookingTest(t)
+	in.requireApproval(t)
+
+	asset := in.getAsset(t)
+	asset.Operators = []sdk.Address{stranger}
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	booking, err = in.keeper.Approve(in.signedBy(stranger), messages.NewMsgApproveBooking(booking.BookingID))
+	require.Nil(t, err)
+	require.Equal(t, types.BOOKING_CONFIRMED, booking.State)
+
+	// Earnings still go to the owner
+	_, _, err = in.keeper.Complete(in.atTime(in.signedBy(renter), now+2*hour), messages.NewMsgComplete(booking.BookingID))
+	require.Nil(t, err)
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)))
+	require.True(t, in.balance(stranger).IsZero())
+}
+
+func TestRevenueSplitByShares(t *testing.T) {
+	in := setupBookingTest(t)
+
+	asset := in.getAsset(t)
+	asset.Shares = []types.Share{
+		types.NewShare(owner, 5000),
+		types.NewShare(stranger, 3000),
+		types.NewShare(arbiter, 2000),
+	}
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+
+	ctx := in.signedBy(renter)
+	booking, err := in.keeper.Book(ctx, messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	booking, _, err = in.keeper.Complete(in.atTime(ctx, now+2*hour), messages.NewMsgComplete(booking.BookingID))
+	require.Nil(t, err)
+
+	require.Len(t, booking.Payouts, 3)
+	require.Equal(t, types.PAYOUT_OWNER, booking.Payouts[0].Role)
+	require.Equal(t, types.PAYOUT_SHAREHOLDER, booking.Payouts[1].Role)
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 5)))
+	require.True(t, in.balance(stranger).Equal(types.NewCoin(constants.BOOKING_DENOM, 3)))
+	require.True(t, in.balance(arbiter).Equal(types.NewCoin(constants.BOOKING_DENOM, 2)))
+	require.True(t, in.escrow(booking.BookingID).IsZero())
+}
+
+func (in testInput) setShares(t *testing.T, holder sdk.Address, amount int64) {
+	asset := in.getAsset(t)
+	asset.Shares = []types.Share{
+		types.NewShare(owner, constants.ASSET_TOTAL_SHARES-amount),
+		types.NewShare(holder, amount),
+	}
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+}
+
+func TestDisputeRulingSplitByShares(t *testing.T) {
+	in := setupBookingTest(t)
+	in.setDepositAndArbiter(t, 100, arbiter)
+
+	holderPub, _ := types.GenerateKeyPair()
+	holder := holderPub.Address()
+	in.setShares(t, holder, 4000)
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+	_, err = in.keeper.OpenDispute(in.signedBy(renter), messages.NewMsgOpenDispute(booking.BookingID, "broken"))
+	require.Nil(t, err)
+
+	booking, _, err = in.keeper.ResolveDispute(in.signedBy(arbiter), messages.NewMsgResolveDispute(booking.BookingID, 10))
+	require.Nil(t, err)
+
+	require.Len(t, booking.Payouts, 2)
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 60)))
+	require.True(t, in.balance(holder).Equal(types.NewCoin(constants.BOOKING_DENOM, 40)))
+	require.True(t, in.escrow(booking.BookingID).IsZero())
+}
+
+func TestLateFeeSplitByShares(t *testing.T) {
+	in := setupBookingTest(t)
+	in.setShares(t, stranger, 4000)
+
+	asset := in.getAsset(t)
+	asset.LateFee = 400
+	require.Nil(t, utils.Store(in.ctx.KVStore(in.assetKey), []byte(asset.UUID), asset))
+
+	booking, err := in.keeper.Book(in.signedBy(renter), messages.NewMsgBook("asset-1", now+hour, now+2*hour))
+	require.Nil(t, err)
+
+	EndBlocker(in.atTime(in.ctx, now+2*hour+constants.BOOKING_GRACE_PERIOD), in.keeper)
+	booking, _ = in.keeper.GetBooking(in.ctx, booking.BookingID)
+	require.Equal(t, int64(1), booking.LatePeriods)
+
+	require.True(t, in.balance(owner).Equal(types.NewCoin(constants.BOOKING_DENOM, 240)))
+	require.True(t, in.balance(stranger).Equal(types.NewCoin(constants.BOOKING_DENOM, 160)))
+}
@@ -0,0 +1,120 @@
+package booking
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	msg "github.com/sharering/shareledger/x/booking/messages"
+)
+
+// Bookings are stored under their raw ID. Indexes use a non printable
+// prefix so they never collide with a booking ID.
+var (
+	DepositQueueKey = []byte{0x01} // prefix for deposits waiting for settlement, ordered by time
+	DisputeQueueKey = []byte{0x02} // prefix for open disputes waiting for timeout, ordered by time
+	SequenceKey     = []byte{0x03} // number of bookings created so far
+	StartQueueKey   = []byte{0x04} // prefix for confirmed bookings waiting for their start time
+	ExpiryQueueKey  = []byte{0x05} // prefix for bookings to expire or charge a late fee, ordered by time
+	ParamKey        = []byte{0x06} // key for the booking module params
+	ReputationKey   = []byte{0x07} // prefix for aggregate ratings received by an account
+	AssetRatingKey  = []byte{0x08} // prefix for aggregate ratings received by an asset
+	RenterIndexKey  = []byte{0x09} // prefix for bookings of a renter
+	AssetIndexKey   = []byte{0x0A} // prefix for bookings of an asset
+	ActiveIndexKey  = []byte{0x0B} // prefix for bookings in the active state
+)
+
+// GetBookingID - full sha256 over chain data of the booking transaction.
+// Sequence orders bookings within a block, nonce orders transactions of the renter.
+func GetBookingID(height int64, sequence int64, renter sdk.Address, nonce int64, book msg.MsgBook) (string, error) {
+	enc, err := json.Marshal(struct {
+		Height   int64       `json:"height"`
+		Sequence int64       `json:"sequence"`
+		Renter   sdk.Address `json:"renter"`
+		Nonce    int64       `json:"nonce"`
+		Msg      msg.MsgBook `json:"msg"`
+	}{height, sequence, renter, nonce, book})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(enc)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// gets the key of a booking queued under prefix at time
+// VALUE: booking ID
+func GetQueueKey(prefix []byte, time int64, bookingID string) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(time))
+	return append(append(append([]byte{}, prefix...), bz...), []byte(bookingID)...)
+}
+
+// gets the key of a deposit settling at time
+func GetDepositQueueKey(time int64, bookingID string) []byte {
+	return GetQueueKey(DepositQueueKey, time, bookingID)
+}
+
+// gets the key of a dispute timing out at time
+func GetDisputeQueueKey(time int64, bookingID string) []byte {
+	return GetQueueKey(DisputeQueueKey, time, bookingID)
+}
+
+// gets the key of a booking becoming active at time
+func GetStartQueueKey(time int64, bookingID string) []byte {
+	return GetQueueKey(StartQueueKey, time, bookingID)
+}
+
+// gets the key of a booking checked for expiry at time
+func GetExpiryQueueKey(time int64, bookingID string) []byte {
+	return GetQueueKey(ExpiryQueueKey, time, bookingID)
+}
+
+// gets the key of the reputation of an account
+func GetReputationKey(addr sdk.Address) []byte {
+	return append(append([]byte{}, ReputationKey...), addr...)
+}
+
+// gets the key of the rating of an asset
+func GetAssetRatingKey(uuid string) []byte {
+	return append(append([]byte{}, AssetRatingKey...), []byte(uuid)...)
+}
+
+// gets the prefix of all bookings of renter
+func GetRenterIndexPrefix(renter sdk.Address) []byte {
+	return append(append([]byte{}, RenterIndexKey...), renter...)
+}
+
+// gets the key of a booking in the index of its renter
+// VALUE: booking ID
+func GetRenterIndexKey(renter sdk.Address, bookingID string) []byte {
+	return append(GetRenterIndexPrefix(renter), []byte(bookingID)...)
+}
+
+// gets the prefix of all bookings of an asset. The UUID is length prefixed
+// so that one UUID is never a prefix of another.
+func GetAssetIndexPrefix(uuid string) []byte {
+	bz := make([]byte, 2)
+	binary.BigEndian.PutUint16(bz, uint16(len(uuid)))
+	return append(append(append([]byte{}, AssetIndexKey...), bz...), []byte(uuid)...)
+}
+
+// gets the key of a booking in the index of its asset
+// VALUE: booking ID
+func GetAssetIndexKey(uuid string, bookingID string) []byte {
+	return append(GetAssetIndexPrefix(uuid), []byte(bookingID)...)
+}
+
+// gets the key of an active booking
+// VALUE: booking ID
+func GetActiveIndexKey(bookingID string) []byte {
+	return append(append([]byte{}, ActiveIndexKey...), []byte(bookingID)...)
+}
+
+// gets the time encoded in a queue key
+func getQueueTime(prefix []byte, key []byte) int64 {
+	return int64(binary.BigEndian.Uint64(key[len(prefix) : len(prefix)+8]))
+}
@@ -0,0 +1,114 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+//----------------------------------------------------------------
+// MsgApproveBooking
+
+// MsgApproveBooking - owner confirms a booking of an asset requiring approval
+type MsgApproveBooking struct {
+	BookingID string `json:"bookingId"`
+}
+
+var _ sdk.Msg = MsgApproveBooking{}
+
+func NewMsgApproveBooking(bookingId string) MsgApproveBooking {
+	return MsgApproveBooking{
+		BookingID: bookingId,
+	}
+}
+
+func (msg MsgApproveBooking) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgApproveBooking) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("BookingID is empty")
+	}
+
+	return nil
+}
+
+func (msg MsgApproveBooking) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgApproveBooking) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgApproveBooking) String() string {
+	return fmt.Sprintf("Booking/MsgApproveBooking{BookingID: %s}", msg.BookingID)
+}
+
+func (msg MsgApproveBooking) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+func (msg MsgApproveBooking) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.BookingApproved).
+		AppendTag(tags.BookingId, []byte(msg.BookingID))
+}
+
+//----------------------------------------------------------------
+// MsgRejectBooking
+
+// MsgRejectBooking - owner declines a booking request. Renter is refunded in full.
+type MsgRejectBooking struct {
+	BookingID string `json:"bookingId"`
+}
+
+var _ sdk.Msg = MsgRejectBooking{}
+
+func NewMsgRejectBooking(bookingId string) MsgRejectBooking {
+	return MsgRejectBooking{
+		BookingID: bookingId,
+	}
+}
+
+func (msg MsgRejectBooking) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgRejectBooking) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("BookingID is empty")
+	}
+
+	return nil
+}
+
+func (msg MsgRejectBooking) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgRejectBooking) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgRejectBooking) String() string {
+	return fmt.Sprintf("Booking/MsgRejectBooking{BookingID: %s}", msg.BookingID)
+}
+
+func (msg MsgRejectBooking) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+func (msg MsgRejectBooking) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.BookingRejected).
+		AppendTag(tags.BookingId, []byte(msg.BookingID))
+}
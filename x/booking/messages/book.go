@@ -3,6 +3,7 @@ package messages
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
 	"github.com/sharering/shareledger/constants"
@@ -10,16 +11,19 @@ import (
 )
 
 type MsgBook struct {
-	UUID     string `json:"uuid"`
-	Duration int64  `json:"duration"`
+	UUID      string      `json:"uuid"`
+	StartTime int64       `json:"start_time"`         // unix time
+	EndTime   int64       `json:"end_time"`           // unix time
+	Referrer  sdk.Address `json:"referrer,omitempty"` // optional affiliate earning a share of the revenue
 }
 
 var _ sdk.Msg = MsgBook{}
 
-func NewMsgBook(uuid string, duration int64) MsgBook {
+func NewMsgBook(uuid string, startTime int64, endTime int64) MsgBook {
 	return MsgBook{
-		UUID:     uuid,
-		Duration: duration,
+		UUID:      uuid,
+		StartTime: startTime,
+		EndTime:   endTime,
 	}
 }
 
@@ -32,6 +36,12 @@ func (msg MsgBook) ValidateBasic() sdk.Error {
 	//return sdk.ErrInvalidAddress("Invalid address")
 	//}
 
+	if msg.EndTime <= msg.StartTime {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_WINDOW,
+			msg.EndTime,
+			msg.StartTime))
+	}
+
 	return nil
 }
 
@@ -47,7 +57,8 @@ func (msg MsgBook) GetSignBytes() []byte {
 func (msg MsgBook) Get(key interface{}) (value interface{}) { return nil }
 
 func (msg MsgBook) String() string {
-	return fmt.Sprintf("Booking/MsgBook{UUID: %s}", msg.UUID)
+	return fmt.Sprintf("Booking/MsgBook{UUID: %s, StartTime: %d, EndTime: %d}",
+		msg.UUID, msg.StartTime, msg.EndTime)
 }
 
 func (msg MsgBook) GetSigners() []sdk.Address {
@@ -57,5 +68,7 @@ func (msg MsgBook) GetSigners() []sdk.Address {
 
 func (msg MsgBook) Tags() sdk.Tags {
 	return sdk.NewTags(tags.Event, tags.BookingStarted).
-		AppendTag(tags.UUID, []byte(msg.UUID))
+		AppendTag(tags.UUID, []byte(msg.UUID)).
+		AppendTag(tags.StartTime, []byte(strconv.FormatInt(msg.StartTime, 10))).
+		AppendTag(tags.EndTime, []byte(strconv.FormatInt(msg.EndTime, 10)))
 }
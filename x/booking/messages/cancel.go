@@ -0,0 +1,59 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+// MsgCancelBooking - cancel a booking. Can be sent by either renter or asset owner.
+type MsgCancelBooking struct {
+	BookingID string `json:"bookingId"`
+}
+
+var _ sdk.Msg = MsgCancelBooking{}
+
+func NewMsgCancelBooking(bookingId string) MsgCancelBooking {
+	return MsgCancelBooking{
+		BookingID: bookingId,
+	}
+}
+
+func (msg MsgCancelBooking) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgCancelBooking) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("BookingID is empty")
+	}
+
+	return nil
+}
+
+func (msg MsgCancelBooking) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgCancelBooking) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgCancelBooking) String() string {
+	return fmt.Sprintf("Booking/MsgCancelBooking{BookingID: %s}", msg.BookingID)
+}
+
+func (msg MsgCancelBooking) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+func (msg MsgCancelBooking) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.BookingCancelled).
+		AppendTag(tags.BookingId, []byte(msg.BookingID))
+}
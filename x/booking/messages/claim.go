@@ -0,0 +1,73 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+// MsgClaimDamage - claim part of the deposit of a completed booking. Sent by asset owner.
+type MsgClaimDamage struct {
+	BookingID string   `json:"bookingId"`
+	Amount    int64    `json:"amount"`
+	Evidence  [][]byte `json:"evidence"` // hashes of off-chain evidence
+}
+
+var _ sdk.Msg = MsgClaimDamage{}
+
+func NewMsgClaimDamage(bookingId string, amount int64, evidence [][]byte) MsgClaimDamage {
+	return MsgClaimDamage{
+		BookingID: bookingId,
+		Amount:    amount,
+		Evidence:  evidence,
+	}
+}
+
+func (msg MsgClaimDamage) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgClaimDamage) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("BookingID is empty")
+	}
+
+	if msg.Amount <= 0 {
+		return sdk.ErrUnknownRequest("Claimed amount is not positive")
+	}
+
+	if len(msg.Evidence) == 0 {
+		return sdk.ErrUnknownRequest("Evidence is empty")
+	}
+
+	return nil
+}
+
+func (msg MsgClaimDamage) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgClaimDamage) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgClaimDamage) String() string {
+	return fmt.Sprintf("Booking/MsgClaimDamage{BookingID: %s, Amount: %d}", msg.BookingID, msg.Amount)
+}
+
+func (msg MsgClaimDamage) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+func (msg MsgClaimDamage) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.DamageClaimed).
+		AppendTag(tags.BookingId, []byte(msg.BookingID)).
+		AppendTag(tags.Amount, []byte(strconv.FormatInt(msg.Amount, 10)))
+}
@@ -0,0 +1,125 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+//----------------------------------------------------------------
+// MsgOpenDispute
+
+// MsgOpenDispute - freeze funds of a booking until an arbiter rules. Sent by renter or owner.
+type MsgOpenDispute struct {
+	BookingID string `json:"bookingId"`
+	Reason    string `json:"reason"`
+}
+
+var _ sdk.Msg = MsgOpenDispute{}
+
+func NewMsgOpenDispute(bookingId string, reason string) MsgOpenDispute {
+	return MsgOpenDispute{
+		BookingID: bookingId,
+		Reason:    reason,
+	}
+}
+
+func (msg MsgOpenDispute) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgOpenDispute) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("BookingID is empty")
+	}
+
+	return nil
+}
+
+func (msg MsgOpenDispute) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgOpenDispute) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgOpenDispute) String() string {
+	return fmt.Sprintf("Booking/MsgOpenDispute{BookingID: %s}", msg.BookingID)
+}
+
+func (msg MsgOpenDispute) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+func (msg MsgOpenDispute) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.DisputeOpened).
+		AppendTag(tags.BookingId, []byte(msg.BookingID))
+}
+
+//----------------------------------------------------------------
+// MsgResolveDispute
+
+// MsgResolveDispute - arbiter ruling. RenterAmount of the escrowed funds goes
+// to renter and the rest to the asset owner.
+type MsgResolveDispute struct {
+	BookingID    string `json:"bookingId"`
+	RenterAmount int64  `json:"renter_amount"`
+}
+
+var _ sdk.Msg = MsgResolveDispute{}
+
+func NewMsgResolveDispute(bookingId string, renterAmount int64) MsgResolveDispute {
+	return MsgResolveDispute{
+		BookingID:    bookingId,
+		RenterAmount: renterAmount,
+	}
+}
+
+func (msg MsgResolveDispute) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgResolveDispute) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("BookingID is empty")
+	}
+
+	if msg.RenterAmount < 0 {
+		return sdk.ErrUnknownRequest("Renter amount is negative")
+	}
+
+	return nil
+}
+
+func (msg MsgResolveDispute) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgResolveDispute) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgResolveDispute) String() string {
+	return fmt.Sprintf("Booking/MsgResolveDispute{BookingID: %s, RenterAmount: %d}", msg.BookingID, msg.RenterAmount)
+}
+
+func (msg MsgResolveDispute) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+func (msg MsgResolveDispute) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.DisputeResolved).
+		AppendTag(tags.BookingId, []byte(msg.BookingID)).
+		AppendTag(tags.Refund, []byte(strconv.FormatInt(msg.RenterAmount, 10)))
+}
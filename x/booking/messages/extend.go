@@ -0,0 +1,67 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+// MsgExtendBooking - renter keeps the asset for Periods more time units
+type MsgExtendBooking struct {
+	BookingID string `json:"bookingId"`
+	Periods   int64  `json:"periods"`
+}
+
+var _ sdk.Msg = MsgExtendBooking{}
+
+func NewMsgExtendBooking(bookingId string, periods int64) MsgExtendBooking {
+	return MsgExtendBooking{
+		BookingID: bookingId,
+		Periods:   periods,
+	}
+}
+
+func (msg MsgExtendBooking) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgExtendBooking) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("BookingID is empty")
+	}
+
+	if msg.Periods <= 0 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_EXTENSION, msg.Periods))
+	}
+
+	return nil
+}
+
+func (msg MsgExtendBooking) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgExtendBooking) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgExtendBooking) String() string {
+	return fmt.Sprintf("Booking/MsgExtendBooking{BookingID: %s, Periods: %d}", msg.BookingID, msg.Periods)
+}
+
+func (msg MsgExtendBooking) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+func (msg MsgExtendBooking) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.BookingExtended).
+		AppendTag(tags.BookingId, []byte(msg.BookingID)).
+		AppendTag(tags.Periods, []byte(strconv.FormatInt(msg.Periods, 10)))
+}
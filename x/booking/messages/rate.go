@@ -0,0 +1,71 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+// MsgRate - a party of a completed booking rates the other one. Renter rates
+// the owner and the asset, owner rates the renter.
+type MsgRate struct {
+	BookingID  string `json:"bookingId"`
+	Score      int64  `json:"score"`
+	ReviewHash []byte `json:"review_hash,omitempty"` // hash of the off-chain review content
+}
+
+var _ sdk.Msg = MsgRate{}
+
+func NewMsgRate(bookingId string, score int64, reviewHash []byte) MsgRate {
+	return MsgRate{
+		BookingID:  bookingId,
+		Score:      score,
+		ReviewHash: reviewHash,
+	}
+}
+
+func (msg MsgRate) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgRate) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("BookingID is empty")
+	}
+
+	if msg.Score < constants.RATING_MIN_SCORE || msg.Score > constants.RATING_MAX_SCORE {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_SCORE,
+			msg.Score, constants.RATING_MIN_SCORE, constants.RATING_MAX_SCORE))
+	}
+
+	return nil
+}
+
+func (msg MsgRate) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgRate) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgRate) String() string {
+	return fmt.Sprintf("Booking/MsgRate{BookingID: %s, Score: %d}", msg.BookingID, msg.Score)
+}
+
+func (msg MsgRate) GetSigners() []sdk.Address {
+	return []sdk.Address{}
+}
+
+func (msg MsgRate) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.RatingGiven).
+		AppendTag(tags.BookingId, []byte(msg.BookingID)).
+		AppendTag(tags.Score, []byte(strconv.FormatInt(msg.Score, 10)))
+}
@@ -0,0 +1,63 @@
+package booking
+
+import (
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// Params defines how booking revenue is split at settlement
+type Params struct {
+	Commission    types.Dec   `json:"commission"`     // share of revenue paid to the platform treasury
+	Treasury      sdk.Address `json:"treasury"`       // platform account receiving the commission
+	ReferralShare types.Dec   `json:"referral_share"` // share of revenue paid to the referrer of a booking, if any
+}
+
+// DefaultParams returns a default set of parameters. Owners receive all revenue.
+func DefaultParams() Params {
+	return Params{
+		Commission:    types.ZeroDec(),
+		ReferralShare: types.ZeroDec(),
+	}
+}
+
+// Validate - shares must be between 0 and 1 and leave something to the owner
+func (p Params) Validate() error {
+	if p.Commission.IsNil() || p.ReferralShare.IsNil() ||
+		!p.Commission.IsNotNegative() || !p.ReferralShare.IsNotNegative() ||
+		p.Commission.Add(p.ReferralShare).GT(types.OneDec()) {
+		return fmt.Errorf(constants.BOOKING_INVALID_SHARES,
+			p.Commission,
+			p.ReferralShare)
+	}
+
+	if p.Commission.IsPositive() && len(p.Treasury) == 0 {
+		return fmt.Errorf(constants.BOOKING_MISSING_TREASURY)
+	}
+
+	return nil
+}
+
+// GetParams - stored parameters, defaults if none were set at genesis
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	store := ctx.KVStore(k.bookingKey)
+	b := store.Get(ParamKey)
+	if b == nil {
+		return DefaultParams()
+	}
+
+	k.cdc.MustUnmarshalBinary(b, &params)
+	return
+}
+
+// set the params
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	store := ctx.KVStore(k.bookingKey)
+
+	b := k.cdc.MustMarshalBinary(params)
+
+	store.Set(ParamKey, b)
+}
@@ -0,0 +1,247 @@
+package booking
+
+import (
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	wire "bitbucket.org/shareringvn/cosmos-sdk/wire"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+
+	abci "github.com/tendermint/abci/types"
+)
+
+// query endpoints supported by the booking Querier
+const (
+	QueryEscrow      = "escrow"
+	QueryReputation  = "reputation"
+	QueryAssetRating = "asset_rating"
+	QueryRenter      = "renter"
+	QueryAsset       = "asset"
+	QueryActive      = "active"
+)
+
+// creates a querier for booking REST endpoints
+func NewQuerier(k Keeper, cdc *wire.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryEscrow:
+			return queryEscrow(ctx, cdc, req, k)
+		case QueryReputation:
+			return queryReputation(ctx, cdc, req, k)
+		case QueryAssetRating:
+			return queryAssetRating(ctx, cdc, req, k)
+		case QueryRenter:
+			return queryRenter(ctx, cdc, req, k)
+		case QueryAsset:
+			return queryAsset(ctx, cdc, req, k)
+		case QueryActive:
+			return queryActive(ctx, cdc, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown booking query endpoint")
+		}
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/booking/escrow'
+type QueryBookingParams struct {
+	BookingID string
+}
+
+func queryEscrow(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k Keeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryBookingParams
+
+	errRes := cdc.UnmarshalBinary(req.Data, &params)
+	if errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_PARAMS, errRes.Error()))
+	}
+
+	if _, found := k.GetBooking(ctx, params.BookingID); !found {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_NOT_FOUND, params.BookingID))
+	}
+
+	res, errRes = cdc.MarshalJSON(k.GetEscrowBalance(ctx, params.BookingID))
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.BOOKING_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
+
+// defines the params for the following queries:
+// - 'custom/booking/reputation'
+type QueryReputationParams struct {
+	Address sdk.Address
+}
+
+// defines the params for the following queries:
+// - 'custom/booking/asset_rating'
+type QueryAssetParams struct {
+	UUID string
+}
+
+// aggregate ratings returned by reputation queries
+type QueryReputationResult struct {
+	Count   int64     `json:"count"`
+	Total   int64     `json:"total"`
+	Average types.Dec `json:"average"`
+}
+
+func NewQueryReputationResult(r types.Reputation) QueryReputationResult {
+	return QueryReputationResult{
+		Count:   r.Count,
+		Total:   r.Total,
+		Average: r.Average(),
+	}
+}
+
+func queryReputation(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k Keeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryReputationParams
+
+	errRes := cdc.UnmarshalBinary(req.Data, &params)
+	if errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_PARAMS, errRes.Error()))
+	}
+
+	res, errRes = cdc.MarshalJSON(NewQueryReputationResult(k.GetReputation(ctx, params.Address)))
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.BOOKING_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
+
+func queryAssetRating(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k Keeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryAssetParams
+
+	errRes := cdc.UnmarshalBinary(req.Data, &params)
+	if errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_PARAMS, errRes.Error()))
+	}
+
+	res, errRes = cdc.MarshalJSON(NewQueryReputationResult(k.GetAssetRating(ctx, params.UUID)))
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.BOOKING_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
+
+// defines the params for the following queries:
+// - 'custom/booking/renter'
+type QueryRenterParams struct {
+	Renter sdk.Address
+	Page   int
+	Limit  int
+}
+
+// defines the params for the following queries:
+// - 'custom/booking/asset'
+type QueryAssetBookingsParams struct {
+	UUID  string
+	Page  int
+	Limit int
+}
+
+// defines the params for the following queries:
+// - 'custom/booking/active'
+type QueryActiveParams struct {
+	Page  int
+	Limit int
+}
+
+func queryRenter(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k Keeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryRenterParams
+
+	errRes := cdc.UnmarshalBinary(req.Data, &params)
+	if errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_PARAMS, errRes.Error()))
+	}
+
+	return marshalBookings(cdc, k.GetBookingsByRenter(ctx, params.Renter, params.Page, params.Limit))
+}
+
+func queryAsset(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k Keeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryAssetBookingsParams
+
+	errRes := cdc.UnmarshalBinary(req.Data, &params)
+	if errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_PARAMS, errRes.Error()))
+	}
+
+	return marshalBookings(cdc, k.GetBookingsByAsset(ctx, params.UUID, params.Page, params.Limit))
+}
+
+func queryActive(
+	ctx sdk.Context,
+	cdc *wire.Codec,
+	req abci.RequestQuery,
+	k Keeper,
+) (
+	res []byte, err sdk.Error,
+) {
+	var params QueryActiveParams
+
+	errRes := cdc.UnmarshalBinary(req.Data, &params)
+	if errRes != nil {
+		return []byte{},
+			sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_PARAMS, errRes.Error()))
+	}
+
+	return marshalBookings(cdc, k.GetActiveBookings(ctx, params.Page, params.Limit))
+}
+
+func marshalBookings(cdc *wire.Codec, bookings []types.Booking) ([]byte, sdk.Error) {
+	res, err := cdc.MarshalJSON(bookings)
+	if err != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.BOOKING_MARSHAL_ERROR, err.Error()))
+	}
+	return res, nil
+}
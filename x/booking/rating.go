@@ -0,0 +1,95 @@
+package booking
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	msg "github.com/sharering/shareledger/x/booking/messages"
+)
+
+// Rate - record the rating of a completed booking by one of its parties and
+// add it to the reputation of the other party. Each party rates once.
+// Returns the rated address.
+func (k Keeper) Rate(ctx sdk.Context, msg msg.MsgRate) (types.Booking, sdk.Address, error) {
+
+	booking, found := k.GetBooking(ctx, msg.BookingID)
+	if !found {
+		return types.Booking{}, nil, fmt.Errorf(constants.BOOKING_NOT_FOUND,
+			msg.BookingID)
+	}
+
+	if booking.State != types.BOOKING_COMPLETED {
+		return types.Booking{}, nil, fmt.Errorf(constants.BOOKING_NOT_COMPLETED,
+			booking.BookingID)
+	}
+
+	asset, err := k.getAsset(ctx, booking.UUID)
+	if err != nil {
+		return types.Booking{}, nil, err
+	}
+
+	signer := auth.GetSigner(ctx).GetAddress()
+	rating := types.NewRating(signer, msg.Score, msg.ReviewHash, ctx.BlockHeader().Time)
+
+	var rated sdk.Address
+	switch {
+	case bytes.Equal(signer, booking.Renter):
+		if booking.RenterRating != nil {
+			return types.Booking{}, nil, fmt.Errorf(constants.BOOKING_ALREADY_RATED,
+				booking.BookingID)
+		}
+		booking.RenterRating = &rating
+		rated = asset.Creator
+
+		k.setAssetRating(ctx, asset.UUID, k.GetAssetRating(ctx, asset.UUID).Add(msg.Score))
+
+	case bytes.Equal(signer, asset.Creator):
+		if booking.OwnerRating != nil {
+			return types.Booking{}, nil, fmt.Errorf(constants.BOOKING_ALREADY_RATED,
+				booking.BookingID)
+		}
+		booking.OwnerRating = &rating
+		rated = booking.Renter
+
+	default:
+		return types.Booking{}, nil, fmt.Errorf(constants.BOOKING_RATE_UNAUTHORIZED,
+			utils.ByteToString(signer),
+			booking.BookingID)
+	}
+
+	k.setReputation(ctx, rated, k.GetReputation(ctx, rated).Add(msg.Score))
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, nil, err
+	}
+
+	return booking, rated, nil
+}
+
+// GetReputation - aggregate of the ratings received by an account
+func (k Keeper) GetReputation(ctx sdk.Context, addr sdk.Address) types.Reputation {
+	var reputation types.Reputation
+	utils.Retrieve(ctx.KVStore(k.bookingKey), GetReputationKey(addr), &reputation)
+	return reputation
+}
+
+func (k Keeper) setReputation(ctx sdk.Context, addr sdk.Address, reputation types.Reputation) {
+	utils.Store(ctx.KVStore(k.bookingKey), GetReputationKey(addr), reputation)
+}
+
+// GetAssetRating - aggregate of the ratings received by an asset
+func (k Keeper) GetAssetRating(ctx sdk.Context, uuid string) types.Reputation {
+	var reputation types.Reputation
+	utils.Retrieve(ctx.KVStore(k.bookingKey), GetAssetRatingKey(uuid), &reputation)
+	return reputation
+}
+
+func (k Keeper) setAssetRating(ctx sdk.Context, uuid string, reputation types.Reputation) {
+	utils.Store(ctx.KVStore(k.bookingKey), GetAssetRatingKey(uuid), reputation)
+}
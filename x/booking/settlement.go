@@ -0,0 +1,69 @@
+package booking
+
+import (
+	"bytes"
+
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// settle - pay amount of booking revenue from escrow. The platform commission
+// goes to the treasury and the referral share to the referrer of the booking,
+// the owners of the asset receive the rest pro-rata to their shares. Shares are
+// computed in types.Dec and the managing owner receives the remainder so that
+// the payouts add up to amount exactly. Payouts are recorded on the booking.
+func (k Keeper) settle(ctx sdk.Context, booking *types.Booking, asset types.Asset, amount int64) error {
+	if amount == 0 {
+		return nil
+	}
+
+	params := k.GetParams(ctx)
+
+	revenue := types.NewDec(amount)
+	commission := revenue.Mul(params.Commission)
+
+	referral := types.ZeroDec()
+	if len(booking.Referrer) > 0 {
+		referral = revenue.Mul(params.ReferralShare)
+	}
+
+	payouts := splitByShares(asset, revenue.Sub(commission).Sub(referral))
+	if commission.IsPositive() {
+		payouts = append(payouts, types.NewPayout(types.PAYOUT_TREASURY, params.Treasury, commission))
+	}
+	if referral.IsPositive() {
+		payouts = append(payouts, types.NewPayout(types.PAYOUT_REFERRER, booking.Referrer, referral))
+	}
+
+	for _, p := range payouts {
+		err := k.releaseFromEscrow(ctx, booking.BookingID, p.Recipient,
+			types.NewCoinFromDec(booking.GetDenom(), p.Amount))
+		if err != nil {
+			return err
+		}
+	}
+
+	booking.Payouts = append(booking.Payouts, payouts...)
+	return nil
+}
+
+// splitByShares - owner payouts of amount following the asset cap table. The
+// managing owner comes first and receives the rounding dust.
+func splitByShares(asset types.Asset, amount types.Dec) []types.Payout {
+	total := types.NewDec(constants.ASSET_TOTAL_SHARES)
+	remainder := amount
+
+	var shareholders []types.Payout
+	for _, s := range asset.GetCapTable() {
+		if bytes.Equal(s.Holder, asset.Creator) {
+			continue
+		}
+		share := amount.Mul(types.NewDec(s.Amount)).Quo(total)
+		remainder = remainder.Sub(share)
+		shareholders = append(shareholders, types.NewPayout(types.PAYOUT_SHAREHOLDER, s.Holder, share))
+	}
+
+	return append([]types.Payout{types.NewPayout(types.PAYOUT_OWNER, asset.Creator, remainder)}, shareholders...)
+}
@@ -7,9 +7,30 @@ var (
 	Event     = "Event"
 	BookingId = "BookingId"
 	UUID      = "UUID"
+	StartTime = "StartTime"
+	EndTime   = "EndTime"
+	Refund    = "Refund"
+	Payout    = "Payout"
+	State     = "State"
+	Periods   = "Periods"
+	Recipient = "Recipient"
+	Score     = "Score"
+	Rated     = "Rated"
 
 	//Value -  []byte
 
 	BookingCompleted = []byte("BookingCompleted")
 	BookingStarted   = []byte("BookingStarted")
+	BookingCancelled = []byte("BookingCancelled")
+	DamageClaimed    = []byte("DamageClaimed")
+	DisputeOpened    = []byte("DisputeOpened")
+	DisputeResolved  = []byte("DisputeResolved")
+	BookingApproved  = []byte("BookingApproved")
+	BookingRejected  = []byte("BookingRejected")
+	BookingActivated = []byte("BookingActivated")
+	BookingExpired   = []byte("BookingExpired")
+	LateFeeCharged   = []byte("LateFeeCharged")
+	BookingExtended  = []byte("BookingExtended")
+	ReturnedEarly    = []byte("ReturnedEarly")
+	RatingGiven      = []byte("RatingGiven")
 )
@@ -15,15 +15,17 @@ import (
 
 // Keeper to store ExchangeRate
 type Keeper struct {
-	storeKey   sdk.StoreKey // key used to access the store from Context
-	bankKeeper bank.Keeper  // bank keeper to swap tokens
+	storeKey     sdk.StoreKey      // key used to access the store from Context
+	bankKeeper   bank.Keeper       // bank keeper to swap tokens
+	supplyKeeper bank.SupplyKeeper // supply keeper to check reserves
 }
 
 // NewKeeper - Return a new keeper
-func NewKeeper(key sdk.StoreKey, bk bank.Keeper) Keeper {
+func NewKeeper(key sdk.StoreKey, bk bank.Keeper, sk bank.SupplyKeeper) Keeper {
 	return Keeper{
-		storeKey:   key,
-		bankKeeper: bk,
+		storeKey:     key,
+		bankKeeper:   bk,
+		supplyKeeper: sk,
 	}
 }
 
@@ -156,7 +158,13 @@ func (k Keeper) SellCoin(
 	// Get balance
 	fromAcc := k.bankKeeper.GetCoins(ctx, account)
 
-	// Already check validity with the message
+	// Coins locked by vesting cannot be exchanged
+	spendable := k.bankKeeper.GetSpendableCoins(ctx, account)
+
+	if !k.supplyKeeper.IsReserve(ctx, reserveAddress) {
+		return fmt.Errorf(constants.EXC_INVALID_RESERVE, reserveAddress.String())
+	}
+
 	reserve := etypes.NewReserve(reserveAddress)
 
 	reserveAcc := reserve.GetCoins(ctx, k.bankKeeper)
@@ -165,9 +173,9 @@ func (k Keeper) SellCoin(
 
 	buyingCoin := exr.Convert(sellingCoin)
 
-	if fromAcc.LT(sellingCoin) || reserveAcc.LT(buyingCoin) {
+	if spendable.LT(sellingCoin) || reserveAcc.LT(buyingCoin) {
 		return fmt.Errorf(constants.EXC_INSUFFICIENT_BALANCE,
-			fromAcc.String(),
+			spendable.String(),
 			sellingCoin.String(),
 			reserveAcc.String(),
 			buyingCoin.String())
@@ -215,7 +223,13 @@ func (k Keeper) BuyCoin(
 	// Get balance
 	fromAcc := k.bankKeeper.GetCoins(ctx, account)
 
-	// Already check validity with the message
+	// Coins locked by vesting cannot be exchanged
+	spendable := k.bankKeeper.GetSpendableCoins(ctx, account)
+
+	if !k.supplyKeeper.IsReserve(ctx, reserveAddress) {
+		return fmt.Errorf(constants.EXC_INVALID_RESERVE, reserveAddress.String())
+	}
+
 	reserve := etypes.NewReserve(reserveAddress)
 
 	reserveAcc := reserve.GetCoins(ctx, k.bankKeeper)
@@ -224,9 +238,9 @@ func (k Keeper) BuyCoin(
 
 	sellingCoin := exr.Obtain(buyingCoin)
 
-	if fromAcc.LT(sellingCoin) || reserveAcc.LT(buyingCoin) {
+	if spendable.LT(sellingCoin) || reserveAcc.LT(buyingCoin) {
 		return fmt.Errorf(constants.EXC_INSUFFICIENT_BALANCE,
-			fromAcc.String(),
+			spendable.String(),
 			sellingCoin.String(),
 			reserveAcc.String(),
 			buyingCoin.String())
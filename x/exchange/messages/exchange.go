@@ -9,7 +9,6 @@ import (
 
 	"github.com/sharering/shareledger/constants"
 	"github.com/sharering/shareledger/types"
-	etypes "github.com/sharering/shareledger/x/exchange/types"
 )
 
 type MsgExchange struct {
@@ -55,10 +54,8 @@ func (msg MsgExchange) ValidateBasic() sdk.Error {
 		return sdk.ErrInternal(fmt.Sprintf(constants.EXC_INVALID_AMOUNT, msg.Amount.String()))
 	}
 
-	reserve := etypes.NewReserve(msg.Reserve)
-
-	if !reserve.IsValid() {
-		return sdk.ErrInternal(fmt.Sprintf("constants.EXC_INVALID_RESERVE, msg.Reserve.String()"))
+	if len(msg.Reserve) == 0 {
+		return sdk.ErrInvalidAddress(fmt.Sprintf(constants.EXC_INVALID_RESERVE, msg.Reserve.String()))
 	}
 
 	return nil
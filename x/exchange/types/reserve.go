@@ -1,13 +1,10 @@
 package types
 
 import (
-	"encoding/hex"
 	"fmt"
 
 	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
-	"github.com/sharering/shareledger/constants"
 	"github.com/sharering/shareledger/types"
-	"github.com/sharering/shareledger/utils"
 	"github.com/sharering/shareledger/x/bank"
 )
 
@@ -21,10 +18,6 @@ func NewReserve(addr sdk.Address) Reserve {
 	}
 }
 
-func (res Reserve) IsValid() bool {
-	return utils.IsValidReserve(res.Address)
-}
-
 func (res Reserve) String() string {
 	return fmt.Sprintf("shareledger/Reserve{%s}", res.Address.String())
 }
@@ -45,21 +38,3 @@ func (res Reserve) SetCoins(
 }
 
 //---------------------------------------------------------------
-func GetAllReserve() []Reserve {
-	var allRes []Reserve
-
-	for _, resStr := range constants.RESERVE_ACCOUNTS {
-		decoded, err := hex.DecodeString(resStr)
-		if err != nil {
-			panic(err)
-		}
-
-		var addr sdk.Address
-		copy(addr[:], decoded)
-
-		allRes = append(allRes, NewReserve(addr))
-	}
-	return allRes
-}
-
-//---------------------------------------------------------------
@@ -13,7 +13,7 @@ import (
 	"github.com/sharering/shareledger/x/exchange"
 )
 
-func NewFeeHandler(am auth.AccountMapper, exchangeKey *sdk.KVStoreKey) sdk.FeeHandler {
+func NewFeeHandler(am auth.AccountMapper, exchangeKey *sdk.KVStoreKey, supplyKeeper bank.SupplyKeeper) sdk.FeeHandler {
 	return func(
 		ctx sdk.Context,
 		result sdk.Result,
@@ -36,7 +36,8 @@ func NewFeeHandler(am auth.AccountMapper, exchangeKey *sdk.KVStoreKey) sdk.FeeHa
 				true
 		}
 
-		signerCoins := keeper.GetCoins(ctx, signer)
+		// coins locked by vesting cannot pay fees
+		signerCoins := keeper.GetSpendableCoins(ctx, signer)
 
 		// if Account is less than txFee
 		if signerCoins.LT(txFee) {
@@ -44,7 +45,7 @@ func NewFeeHandler(am auth.AccountMapper, exchangeKey *sdk.KVStoreKey) sdk.FeeHa
 			deltaCoins := signerCoins.Minus(txFee)
 			deltaCoin := deltaCoins.GetCoin(txFee.Denom).Neg()
 
-			exchangeKeeper := exchange.NewKeeper(exchangeKey, keeper)
+			exchangeKeeper := exchange.NewKeeper(exchangeKey, keeper, supplyKeeper)
 
 			err := exchangeKeeper.BuyCoin(
 				ctx,
@@ -71,6 +72,9 @@ func NewFeeHandler(am auth.AccountMapper, exchangeKey *sdk.KVStoreKey) sdk.FeeHa
 				true
 		}
 
+		// Fees are not paid to anyone, they leave the supply
+		supplyKeeper.Burn(ctx, txFee)
+
 		// if everything succeed, original result
 		return result, false
 	}
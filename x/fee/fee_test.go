@@ -0,0 +1,63 @@
+package fee
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/abci/types"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	"bitbucket.org/shareringvn/cosmos-sdk/store"
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"bitbucket.org/shareringvn/cosmos-sdk/wire"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank"
+)
+
+func makeTestCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	cdc.RegisterInterface((*auth.BaseAccount)(nil), nil)
+	cdc.RegisterConcrete(&auth.SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterInterface((*types.PubKey)(nil), nil)
+	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
+	return cdc
+}
+
+func TestFeeBurnsSupply(t *testing.T) {
+	db := dbm.NewMemDB()
+	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+	exchangeKey := sdk.NewKVStoreKey(constants.STORE_EXCHANGE)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(authKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(bankKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(exchangeKey, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1}, false, log.NewNopLogger())
+
+	am := auth.NewAccountMapper(makeTestCodec(), authKey, &auth.SHRAccount{})
+	sk := bank.NewSupplyKeeper(bankKey)
+
+	signerPub, _ := types.GenerateKeyPair()
+	acc := auth.NewSHRAccountWithAddress(signerPub.Address())
+	acc.SetCoins(acc.Coins.Plus(types.NewCoin(constants.BOOKING_DENOM, 100)))
+	am.SetAccount(ctx, acc)
+	sk.InitSupply(ctx, am, nil)
+
+	ctx = auth.WithSigners(ctx, acc)
+	result := sdk.Result{FeeAmount: 2, FeeDenom: constants.BOOKING_DENOM}
+	_, abort := NewFeeHandler(am, exchangeKey, sk)(ctx, result)
+	require.False(t, abort)
+
+	// The fee leaves the supply with the signer coins
+	coins := am.GetAccount(ctx, acc.GetAddress()).GetCoins()
+	require.True(t, coins.GetCoin(constants.BOOKING_DENOM).Equal(types.NewCoin(constants.BOOKING_DENOM, 98)))
+	require.True(t, sk.GetSupply(ctx, constants.BOOKING_DENOM).Equal(types.NewCoin(constants.BOOKING_DENOM, 98)))
+	require.Nil(t, bank.SupplyInvariant(ctx, am, sk, nil))
+}
@@ -76,7 +76,7 @@ func (k Keeper) Delegate(ctx sdk.Context, delAddr sdk.Address, bondAmt types.Coi
 
 	if subtractAccount {
 		// Account new shares, save
-		_, err = k.bankKeeper.SubtractCoins(ctx, delegation.DelegatorAddr, types.Coins{bondAmt})
+		_, err = k.bankKeeper.DelegateCoins(ctx, delegation.DelegatorAddr, types.Coins{bondAmt})
 		if err != nil {
 			return
 		}
@@ -193,7 +193,7 @@ func (k Keeper) BeginUnbonding(ctx sdk.Context,
 
 	// no need to create the ubd object just complete now
 	if completeNow {
-		_, err := k.bankKeeper.AddCoins(ctx, delAddr, types.Coins{balance})
+		_, err := k.bankKeeper.UndelegateCoins(ctx, delAddr, types.Coins{balance})
 		if err != nil {
 			return err
 		}
@@ -285,7 +285,7 @@ func (k Keeper) CompleteUnbonding(ctx sdk.Context, delAddr sdk.Address, valAddr
 		//return posTypes.ErrNotMature(k.Codespace(), "unbonding", "unit-time", ubd.MinTime, ctxTime)
 	}
 
-	_, err := k.bankKeeper.AddCoins(ctx, ubd.DelegatorAddr, types.Coins{ubd.Balance})
+	_, err := k.bankKeeper.UndelegateCoins(ctx, ubd.DelegatorAddr, types.Coins{ubd.Balance})
 	if err != nil {
 		return err
 	}
@@ -13,19 +13,21 @@ import (
 )
 
 type Keeper struct {
-	storeKey   sdk.StoreKey
-	cdc        *wire.Codec
-	bankKeeper bank.Keeper
+	storeKey     sdk.StoreKey
+	cdc          *wire.Codec
+	bankKeeper   bank.Keeper
+	supplyKeeper bank.SupplyKeeper // minted block rewards are added to the supply
 
 	// codespace
 	codespace sdk.CodespaceType
 }
 
-func NewKeeper(posKey sdk.StoreKey, bk bank.Keeper, cdc *wire.Codec) Keeper {
+func NewKeeper(posKey sdk.StoreKey, bk bank.Keeper, sk bank.SupplyKeeper, cdc *wire.Codec) Keeper {
 	keeper := Keeper{
-		storeKey:   posKey,
-		cdc:        cdc,
-		bankKeeper: bk,
+		storeKey:     posKey,
+		cdc:          cdc,
+		bankKeeper:   bk,
+		supplyKeeper: sk,
 	}
 	return keeper
 }
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"github.com/sharering/shareledger/types"
 	posTypes "github.com/sharering/shareledger/x/pos/type"
 )
 
@@ -22,3 +23,26 @@ func (k Keeper) SetPool(ctx sdk.Context, pool posTypes.Pool) {
 	b := k.cdc.MustMarshalBinary(pool)
 	store.Set(PoolKey, b)
 }
+
+// GetStakedCoins - coins held by staking outside of accounts: tokens of every
+// validator and balances of unbonding delegations not completed yet
+func (k Keeper) GetStakedCoins(ctx sdk.Context) types.Coins {
+	store := ctx.KVStore(k.storeKey)
+	staked := types.ZeroDec()
+
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorsKey)
+	for ; iterator.Valid(); iterator.Next() {
+		validator := posTypes.MustUnmarshalValidator(k.cdc, iterator.Key()[1:], iterator.Value())
+		staked = staked.Add(validator.Tokens)
+	}
+	iterator.Close()
+
+	iterator = sdk.KVStorePrefixIterator(store, UnbondingDelegationKey)
+	for ; iterator.Valid(); iterator.Next() {
+		ubd := posTypes.MustUnmarshalUBD(k.cdc, iterator.Key(), iterator.Value())
+		staked = staked.Add(ubd.Balance.Amount)
+	}
+	iterator.Close()
+
+	return types.Coins{types.NewPOSCoinFromDec(staked)}
+}
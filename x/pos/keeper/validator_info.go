@@ -262,14 +262,10 @@ func (k Keeper) WithdrawDelReward(
 			sdk.ErrInternal(fmt.Sprintf(constants.POS_WITHDRAWAL_ERROR, err.Error()))
 	}
 
-	err = k.bankKeeper.SetCoins(ctx, validatorAddr, after)
 	fmt.Printf("After update balance %v\n", after)
 
-	if err != nil {
-		return vdi,
-			types.NewZeroPOSCoin(),
-			sdk.ErrInternal(fmt.Sprintf(constants.POS_WITHDRAWAL_ERROR, err.Error()))
-	}
+	// Rewards are minted when withdrawn
+	k.supplyKeeper.Mint(ctx, rewardCoin)
 
 	return vdi, rewardCoin, nil
 
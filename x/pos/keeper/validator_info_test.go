@@ -0,0 +1,88 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/abci/types"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	"bitbucket.org/shareringvn/cosmos-sdk/store"
+	sdk "bitbucket.org/shareringvn/cosmos-sdk/types"
+	"bitbucket.org/shareringvn/cosmos-sdk/wire"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/x/auth"
+	bank "github.com/sharering/shareledger/x/bank"
+	posTypes "github.com/sharering/shareledger/x/pos/type"
+)
+
+func makeTestCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	cdc.RegisterInterface((*auth.BaseAccount)(nil), nil)
+	cdc.RegisterConcrete(&auth.SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterInterface((*types.PubKey)(nil), nil)
+	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
+	return cdc
+}
+
+func setupKeeperTest(t *testing.T) (sdk.Context, Keeper, auth.AccountMapper, bank.SupplyKeeper) {
+	db := dbm.NewMemDB()
+	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+	posKey := sdk.NewKVStoreKey(constants.STORE_POS)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(authKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(bankKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(posKey, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 10}, false, log.NewNopLogger())
+
+	cdc := makeTestCodec()
+	am := auth.NewAccountMapper(cdc, authKey, &auth.SHRAccount{})
+	sk := bank.NewSupplyKeeper(bankKey)
+
+	return ctx, NewKeeper(posKey, bank.NewKeeper(am), sk, cdc), am, sk
+}
+
+func TestWithdrawDelRewardKeepsValidatorBalance(t *testing.T) {
+	ctx, k, am, sk := setupKeeperTest(t)
+
+	valPub, _ := types.GenerateKeyPair()
+	delPub, _ := types.GenerateKeyPair()
+	valAddr := valPub.Address()
+	delAddr := delPub.Address()
+
+	valAcc := auth.NewSHRAccountWithAddress(valAddr)
+	valAcc.SetCoins(valAcc.Coins.Plus(types.NewPOSCoin(100)))
+	am.SetAccount(ctx, valAcc)
+	am.SetAccount(ctx, auth.NewSHRAccountWithAddress(delAddr))
+
+	validator := posTypes.NewValidator(valAddr, valPub, posTypes.Description{})
+	validator.DelegatorShares = types.NewDec(10)
+	k.SetValidator(ctx, validator)
+	k.SetValidatorDistInfo(ctx, posTypes.NewValidatorDistInfo(valAddr, 1))
+	k.SetDelegation(ctx, posTypes.Delegation{
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+		Shares:        types.NewDec(10),
+		RewardAccum:   types.NewPOSCoin(5),
+	})
+
+	_, reward, err := k.WithdrawDelReward(ctx, valAddr, delAddr)
+	require.Nil(t, err)
+	require.True(t, reward.Amount.Equal(types.NewDec(5)))
+
+	// Only the delegator is credited, the validator balance is left untouched
+	delCoins := am.GetAccount(ctx, delAddr).GetCoins()
+	require.True(t, delCoins.AmountOf(constants.POS_DENOM).Equal(types.NewDec(5)))
+
+	valCoins := am.GetAccount(ctx, valAddr).GetCoins()
+	require.True(t, valCoins.AmountOf(constants.POS_DENOM).Equal(types.NewDec(100)))
+
+	require.True(t, sk.GetSupply(ctx, constants.POS_DENOM).Amount.Equal(types.NewDec(5)))
+}